@@ -0,0 +1,182 @@
+package book
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory/note"
+)
+
+// Format is the kind of document a book is written as.
+type Format string
+
+const (
+	FormatText     Format = "text"
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+)
+
+// Book is a collection of songs to be printed together.
+type Book struct {
+	Title string
+	Songs []Song
+	// CSS is added to the stylesheet of an HTML book, such as to color the songs.
+	CSS string
+}
+
+// Song is a song in a book. The details are shown in its heading, so the lines only need the song's body.
+type Song struct {
+	Title  string
+	Artist string
+	// Key is the key the song sounds in and Capo is the fret the capo is placed on.
+	Key   string
+	Capo  int
+	Tempo int
+	Time  string
+
+	Lines []songio.Line
+}
+
+// Write writes the book in the format. The book has a title page, a table of contents, each song on its own page, an
+// alphabetical index, and an index by key.
+func Write(noteNamer note.Namer, b *Book, format Format, w io.Writer) error {
+	switch format {
+	case FormatText:
+		return writeText(noteNamer, b, w)
+	case FormatMarkdown:
+		return writeMarkdown(noteNamer, b, w)
+	case FormatHTML:
+		return writeHTML(noteNamer, b, w)
+	default:
+		return fmt.Errorf("unsupported book format %q", format)
+	}
+}
+
+// entry is a song's place in the book, numbered from 1.
+type entry struct {
+	Number int
+	Song   *Song
+}
+
+func (b *Book) entries() []entry {
+	entries := make([]entry, 0, len(b.Songs))
+	for i := range b.Songs {
+		entries = append(entries, entry{Number: i + 1, Song: &b.Songs[i]})
+	}
+
+	return entries
+}
+
+// alphabetical returns the songs ordered by title, ignoring case.
+func (b *Book) alphabetical() []entry {
+	entries := b.entries()
+	sort.SliceStable(entries, func(i, j int) bool {
+		return strings.ToLower(entries[i].Song.Title) < strings.ToLower(entries[j].Song.Title)
+	})
+
+	return entries
+}
+
+// keyGroup is the songs in a key.
+type keyGroup struct {
+	Key     string
+	Entries []entry
+}
+
+// byKey groups the songs by their keys, in the order of the keys' names, and then by title. Songs without a key come
+// last.
+func (b *Book) byKey() []keyGroup {
+	var groups []keyGroup
+	for _, e := range b.alphabetical() {
+		found := false
+		for i := range groups {
+			if groups[i].Key == e.Song.Key {
+				groups[i].Entries = append(groups[i].Entries, e)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			groups = append(groups, keyGroup{Key: e.Song.Key, Entries: []entry{e}})
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if len(groups[i].Key) == 0 || len(groups[j].Key) == 0 {
+			return len(groups[j].Key) == 0 && len(groups[i].Key) > 0
+		}
+		return groups[i].Key < groups[j].Key
+	})
+
+	return groups
+}
+
+// details describes the song's key, capo, tempo, and time signature.
+func (s *Song) details() string {
+	var details []string
+	if len(s.Key) > 0 {
+		details = append(details, "Key: "+s.Key)
+	}
+	if s.Capo > 0 {
+		details = append(details, fmt.Sprintf("Capo: %d", s.Capo))
+	}
+	if s.Tempo > 0 {
+		details = append(details, fmt.Sprintf("Tempo: %d bpm", s.Tempo))
+	}
+	if len(s.Time) > 0 {
+		details = append(details, "Time: "+s.Time)
+	}
+
+	return strings.Join(details, "  ")
+}
+
+// body returns the lines of the song to print under its heading. Directives are dropped, except for comments and key
+// changes, which are given as comments, as are the blank lines before the first line that is printed.
+func (s *Song) body() []songio.Line {
+	var lines []songio.Line
+	keys := 0
+	for _, line := range s.Lines {
+		switch tl := line.(type) {
+		case songio.EmptyLine:
+			if len(lines) > 0 {
+				lines = append(lines, line)
+			}
+		case *songio.SectionStartDirectiveLine, *songio.SectionEndDirectiveLine, *songio.ChordLine, *songio.TextLine, *songio.CommentDirectiveLine:
+			lines = append(lines, line)
+		case *songio.KeyDirectiveLine:
+			keys++
+			if keys > 1 {
+				lines = append(lines, &songio.CommentDirectiveLine{Comment: "Key: " + tl.Key.Name})
+			}
+		}
+	}
+
+	return lines
+}
+
+// textBody returns the body with the comments as lyrics, in parentheses, for formats without directives.
+func (s *Song) textBody() songio.Reader {
+	lines := s.body()
+	for i, line := range lines {
+		if cdl, ok := line.(*songio.CommentDirectiveLine); ok {
+			lines[i] = &songio.TextLine{Text: "(" + cdl.Comment + ")"}
+		}
+	}
+
+	return songio.FromLines(lines)
+}
+
+// noKey is the heading of the songs without a key in the index by key.
+const noKey = "No key"
+
+func keyHeading(k string) string {
+	if len(k) == 0 {
+		return noKey
+	}
+
+	return k
+}
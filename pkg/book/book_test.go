@@ -0,0 +1,83 @@
+package book_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/book"
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "updates the golden files in testdata")
+
+func TestWrite(t *testing.T) {
+	testCases := []struct {
+		format book.Format
+		golden string
+	}{
+		{format: book.FormatText, golden: "book.txt"},
+		{format: book.FormatMarkdown, golden: "book.md"},
+		{format: book.FormatHTML, golden: "book.html"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.Nil(t, book.Write(theory.Default(), testBook(t), tc.format, &buf))
+
+			path := filepath.Join("testdata", tc.golden)
+			if *update {
+				require.Nil(t, os.WriteFile(path, buf.Bytes(), 0644))
+			}
+
+			expected, err := os.ReadFile(path)
+			require.Nil(t, err)
+			require.Equal(t, string(expected), buf.String())
+		})
+	}
+}
+
+func TestWrite_UnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	require.NotNil(t, book.Write(theory.Default(), testBook(t), "pdf", &buf))
+}
+
+// testBook has songs out of alphabetical order, two in the same key, one without a key, and one with a key change.
+func testBook(t *testing.T) *book.Book {
+	song := func(text string) []songio.Line {
+		th := theory.Default()
+		lines, err := songio.ReadAllLines(songio.ReadChordsOverLyrics(th, th, strings.NewReader(text)))
+		require.Nil(t, err)
+		return lines
+	}
+
+	return &book.Book{
+		Title: "Sunday <Songs>",
+		Songs: []book.Song{
+			{
+				Title:  "Morning Has Broken",
+				Artist: "Cat Stevens",
+				Key:    "G",
+				Capo:   2,
+				Tempo:  72,
+				Time:   "3/4",
+				Lines:  song("#title=Morning Has Broken\n#key=G\n\n[Verse]\nG    C   D\nMorning has broken\n#comment=softly\n\n#key=A\n[Chorus]\nA      E\nlike the first morning\n"),
+			},
+			{
+				Title: "Amazing Grace",
+				Key:   "G",
+				Lines: song("#title=Amazing Grace\n[Verse]\nG          G7\nAmazing grace\n"),
+			},
+			{
+				Title: "Be Thou My Vision",
+				Lines: song("#title=Be Thou My Vision\nBe thou my vision\n"),
+			},
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package book
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory/note"
+)
+
+var htmlTemplate = template.Must(template.New("book").Funcs(template.FuncMap{
+	"anchor":     anchor,
+	"keyHeading": keyHeading,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+.page { page-break-before: always; }
+.title-page { text-align: center; margin-top: 30vh; }
+.details { color: #555; }
+a { color: inherit; }
+{{.CSS}}</style>
+</head>
+<body>
+<section class="title-page">
+<h1>{{.Title}}</h1>
+<p>{{len .Songs}} songs</p>
+</section>
+<section class="page">
+<h2>Contents</h2>
+<ol>
+{{- range .Entries}}
+<li><a href="#{{anchor .}}">{{.Song.Title}}</a>{{with .Song.Key}} ({{.}}){{end}}</li>
+{{- end}}
+</ol>
+</section>
+{{- range .Songs}}
+<section class="page" id="{{anchor .Entry}}">
+<h2>{{.Entry.Number}}. {{.Entry.Song.Title}}</h2>
+{{- with .Entry.Song.Artist}}
+<p class="artist"><em>{{.}}</em></p>
+{{- end}}
+{{- with .Details}}
+<p class="details">{{.}}</p>
+{{- end}}
+{{.Body}}</section>
+{{- end}}
+<section class="page">
+<h2>Index</h2>
+<ul>
+{{- range .Alphabetical}}
+<li><a href="#{{anchor .}}">{{.Song.Title}}</a>, {{.Number}}</li>
+{{- end}}
+</ul>
+<h2>Index by Key</h2>
+{{- range .ByKey}}
+<h3>{{keyHeading .Key}}</h3>
+<ul>
+{{- range .Entries}}
+<li><a href="#{{anchor .}}">{{.Song.Title}}</a>, {{.Number}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</section>
+</body>
+</html>
+`))
+
+type htmlBook struct {
+	Title        string
+	CSS          template.CSS
+	Entries      []entry
+	Songs        []htmlSong
+	Alphabetical []entry
+	ByKey        []keyGroup
+}
+
+type htmlSong struct {
+	Entry   entry
+	Details string
+	Body    template.HTML
+}
+
+func writeHTML(noteNamer note.Namer, b *Book, w io.Writer) error {
+	hb := htmlBook{
+		Title:        b.Title,
+		CSS:          template.CSS(songio.HTMLStylesheet + b.CSS),
+		Entries:      b.entries(),
+		Alphabetical: b.alphabetical(),
+		ByKey:        b.byKey(),
+	}
+
+	for _, e := range hb.Entries {
+		var body strings.Builder
+		if _, err := songio.WriteHTML(noteNamer, songio.FromLines(e.Song.body()), &body); err != nil {
+			return fmt.Errorf("writing %q: %w", e.Song.Title, err)
+		}
+
+		hb.Songs = append(hb.Songs, htmlSong{
+			Entry:   e,
+			Details: e.Song.details(),
+			Body:    template.HTML(body.String()),
+		})
+	}
+
+	return htmlTemplate.Execute(w, hb)
+}
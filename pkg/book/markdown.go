@@ -0,0 +1,78 @@
+package book
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory/note"
+)
+
+// markdownPageBreak starts a new page when the Markdown is rendered as HTML and printed.
+const markdownPageBreak = "<div style=\"page-break-after: always;\"></div>\n\n"
+
+func writeMarkdown(noteNamer note.Namer, b *Book, w io.Writer) error {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# %s\n\n", b.Title)
+	fmt.Fprintf(&sb, "%d songs\n\n", len(b.Songs))
+	sb.WriteString(markdownPageBreak)
+
+	sb.WriteString("## Contents\n\n")
+	for _, e := range b.entries() {
+		fmt.Fprintf(&sb, "%d. %s", e.Number, markdownLink(e))
+		if len(e.Song.Key) > 0 {
+			fmt.Fprintf(&sb, " (%s)", e.Song.Key)
+		}
+		sb.WriteByte('\n')
+	}
+	sb.WriteByte('\n')
+
+	for _, e := range b.entries() {
+		sb.WriteString(markdownPageBreak)
+		fmt.Fprintf(&sb, "<a id=\"%s\"></a>\n\n", anchor(e))
+		fmt.Fprintf(&sb, "## %d. %s\n\n", e.Number, e.Song.Title)
+		if len(e.Song.Artist) > 0 {
+			fmt.Fprintf(&sb, "*%s*\n\n", e.Song.Artist)
+		}
+		if details := e.Song.details(); len(details) > 0 {
+			fmt.Fprintf(&sb, "%s\n\n", details)
+		}
+
+		// The chords are aligned to the lyrics with spaces, so the body needs a monospaced font.
+		var body strings.Builder
+		if _, err := songio.WriteChordsOverLyrics(noteNamer, e.Song.textBody(), &body); err != nil {
+			return fmt.Errorf("writing %q: %w", e.Song.Title, err)
+		}
+		fmt.Fprintf(&sb, "```\n%s```\n\n", body.String())
+	}
+
+	sb.WriteString(markdownPageBreak)
+	sb.WriteString("## Index\n\n")
+	for _, e := range b.alphabetical() {
+		fmt.Fprintf(&sb, "- %s, %d\n", markdownLink(e), e.Number)
+	}
+	sb.WriteByte('\n')
+
+	sb.WriteString("## Index by Key\n\n")
+	for _, g := range b.byKey() {
+		fmt.Fprintf(&sb, "### %s\n\n", keyHeading(g.Key))
+		for _, e := range g.Entries {
+			fmt.Fprintf(&sb, "- %s, %d\n", markdownLink(e), e.Number)
+		}
+		sb.WriteByte('\n')
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func markdownLink(e entry) string {
+	return fmt.Sprintf("[%s](#%s)", e.Song.Title, anchor(e))
+}
+
+// anchor is the id of the song's heading, used to link to it.
+func anchor(e entry) string {
+	return fmt.Sprintf("song-%d", e.Number)
+}
@@ -0,0 +1,103 @@
+package book
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory/note"
+)
+
+// pageBreak is the form feed, which starts a new page when the text is printed.
+const pageBreak = "\f"
+
+func writeText(noteNamer note.Namer, b *Book, w io.Writer) error {
+	var sb strings.Builder
+
+	sb.WriteString(textHeading(b.Title, '='))
+	fmt.Fprintf(&sb, "%d songs\n", len(b.Songs))
+	sb.WriteString(pageBreak)
+
+	sb.WriteString(textHeading("Contents", '-'))
+	tw := tabwriter.NewWriter(&sb, 0, 4, 2, ' ', 0)
+	for _, e := range b.entries() {
+		fmt.Fprintf(tw, "%d.\t%s\t%s\n", e.Number, e.Song.Title, e.Song.Key)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, trimTrailingSpaces(sb.String())); err != nil {
+		return err
+	}
+
+	for _, e := range b.entries() {
+		sb.Reset()
+		sb.WriteString(pageBreak)
+		sb.WriteString(textHeading(fmt.Sprintf("%d. %s", e.Number, e.Song.Title), '='))
+		if len(e.Song.Artist) > 0 {
+			sb.WriteString(e.Song.Artist)
+			sb.WriteByte('\n')
+		}
+		details := e.Song.details()
+		if len(details) > 0 {
+			sb.WriteString(details)
+			sb.WriteByte('\n')
+		}
+		if len(e.Song.Artist) > 0 || len(details) > 0 {
+			sb.WriteByte('\n')
+		}
+		if _, err := io.WriteString(w, sb.String()); err != nil {
+			return err
+		}
+
+		body := e.Song.textBody()
+		if _, err := songio.WriteChordsOverLyrics(noteNamer, body, w); err != nil {
+			return fmt.Errorf("writing %q: %w", e.Song.Title, err)
+		}
+	}
+
+	sb.Reset()
+	sb.WriteString(pageBreak)
+	sb.WriteString(textHeading("Index", '-'))
+	tw = tabwriter.NewWriter(&sb, 0, 4, 2, ' ', 0)
+	for _, e := range b.alphabetical() {
+		fmt.Fprintf(tw, "%s\t%d\n", e.Song.Title, e.Number)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	sb.WriteByte('\n')
+	sb.WriteString(textHeading("Index by Key", '-'))
+	tw = tabwriter.NewWriter(&sb, 0, 4, 2, ' ', 0)
+	for _, g := range b.byKey() {
+		fmt.Fprintf(tw, "%s\t\n", keyHeading(g.Key))
+		for _, e := range g.Entries {
+			fmt.Fprintf(tw, "  %s\t%d\n", e.Song.Title, e.Number)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, trimTrailingSpaces(sb.String()))
+	return err
+}
+
+// trimTrailingSpaces removes the padding that a tabwriter leaves at the end of the lines with empty cells.
+func trimTrailingSpaces(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// textHeading underlines the text with the rune.
+func textHeading(text string, underline rune) string {
+	return text + "\n" + strings.Repeat(string(underline), len([]rune(text))) + "\n\n"
+}
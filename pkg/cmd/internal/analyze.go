@@ -0,0 +1,168 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/craiggwilson/songtool/pkg/cmd/internal/config"
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
+)
+
+type AnalyzeCmd struct {
+	songCmd
+
+	JSON     bool `name:"json" help:"Prints the output as JSON."`
+	Patterns bool `name:"patterns" help:"Finds the common chord progressions in each section."`
+	Window   int  `name:"window" default:"8" help:"The number of chords examined at a time when detecting key changes."`
+}
+
+type analysis struct {
+	Key          *key.Named                `json:"key"`
+	DetectedKey  string                    `json:"detectedKey,omitempty"`
+	Modulations  []songio.Modulation       `json:"modulations"`
+	Progressions []songio.ProgressionMatch `json:"progressions,omitempty"`
+}
+
+func (cmd *AnalyzeCmd) Validate() error {
+	if cmd.Window < 1 {
+		return fmt.Errorf("--window must be at least 1, but was %d", cmd.Window)
+	}
+
+	return nil
+}
+
+func (cmd *AnalyzeCmd) Run(cfg *config.Config) error {
+	defer cmd.ensurePath().Close()
+
+	lines, err := songio.ReadAllLines(cmd.openSong(cfg))
+	if err != nil {
+		return err
+	}
+
+	meta, err := songio.ReadMeta(cfg.Theory, songio.FromLines(lines), true)
+	if err != nil {
+		return err
+	}
+
+	var chords []chord.Chord
+	for _, line := range lines {
+		if cl, ok := line.(*songio.ChordLine); ok {
+			for _, co := range cl.Chords {
+				chords = append(chords, co.Chord.Chord)
+			}
+		}
+	}
+
+	result := analysis{
+		Key: meta.Key,
+	}
+
+	var detected *key.Named
+	if dk, ok := harmony.DetectKey(chords[:minInt(cmd.Window, len(chords))]); ok {
+		named, err := cfg.Theory.ParseKey(cfg.Theory.NameKey(dk))
+		if err != nil {
+			return err
+		}
+		detected = &named
+		result.DetectedKey = named.Name
+	}
+
+	// Key changes are only inferred for songs without key changes of their own.
+	if len(meta.Keys) <= 1 {
+		result.Modulations, err = detectModulations(cfg, lines, cmd.Window)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cmd.Patterns {
+		result.Progressions, err = cmd.findProgressions(cfg, lines, meta, detected, result.Modulations)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cmd.JSON {
+		return printJSON(result)
+	}
+
+	return cmd.print(result)
+}
+
+func (cmd *AnalyzeCmd) findProgressions(cfg *config.Config, lines []songio.Line, meta songio.Meta, detected *key.Named, modulations []songio.Modulation) ([]songio.ProgressionMatch, error) {
+	patterns := harmony.DefaultProgressions()
+	for name, numerals := range cfg.Analyze.Progressions {
+		if len(strings.TrimSpace(numerals)) == 0 {
+			delete(patterns, name)
+			continue
+		}
+		patterns[name] = numerals
+	}
+
+	progressions, err := harmony.ParseProgressions(patterns)
+	if err != nil {
+		return nil, fmt.Errorf("analyze.progressions: %w", err)
+	}
+
+	start := detected
+	for _, line := range lines {
+		if _, ok := line.(*songio.KeyDirectiveLine); ok {
+			start = meta.Key
+			break
+		}
+	}
+
+	if start == nil {
+		return nil, nil
+	}
+
+	lines = songio.InsertModulations(lines, modulations)
+
+	return songio.FindProgressions(cfg.Theory, cfg.Theory, lines, *start, progressions)
+}
+
+func (cmd *AnalyzeCmd) print(result analysis) error {
+	if result.Key != nil {
+		fmt.Println("Key:", result.Key.Name)
+	} else {
+		fmt.Println("Key:", "<none>")
+	}
+
+	if len(result.DetectedKey) > 0 {
+		fmt.Println("Detected Key:", result.DetectedKey)
+	} else {
+		fmt.Println("Detected Key:", "<none>")
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	if len(result.Modulations) == 0 {
+		fmt.Fprintln(tw, "Inferred Key Changes:\t<none>")
+	} else {
+		fmt.Fprintln(tw, "Inferred Key Changes:")
+		for _, m := range result.Modulations {
+			fmt.Fprintf(tw, "  line %d\t%s\t%s\t(at %s)\n", m.Line, m.Section, m.Key.Name, m.Chord.Name)
+		}
+	}
+
+	if cmd.Patterns {
+		if len(result.Progressions) == 0 {
+			fmt.Fprintln(tw, "Progressions:\t<none>")
+		} else {
+			fmt.Fprintln(tw, "Progressions:")
+			for _, p := range result.Progressions {
+				names := make([]string, 0, len(p.Chords))
+				for _, c := range p.Chords {
+					names = append(names, c.Name)
+				}
+				fmt.Fprintf(tw, "  line %d\t%s\t%s\t%s in %s\t%s\n", p.Line, p.Section, p.Name, strings.Join(p.Numerals, " "), p.Key.Name, strings.Join(names, " "))
+			}
+		}
+	}
+
+	return tw.Flush()
+}
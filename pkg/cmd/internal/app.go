@@ -27,7 +27,7 @@ func (cmd *AppCmd) Run(cfg *config.Config) error {
 
 		if !fi.IsDir() {
 			appCmds = append(appCmds,
-				message.LoadSong(cmd.Path),
+				message.LoadSong(cmd.Path, 0),
 				message.LoadDirectory(filepath.Dir(cmd.Path)),
 				message.EnterSongMode(),
 			)
@@ -39,6 +39,10 @@ func (cmd *AppCmd) Run(cfg *config.Config) error {
 		}
 	}
 
+	return runApp(cfg, appCmds...)
+}
+
+func runApp(cfg *config.Config, appCmds ...tea.Cmd) error {
 	appModel := app.New(cfg, appCmds...)
 
 	p := tea.NewProgram(
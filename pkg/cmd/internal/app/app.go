@@ -15,10 +15,11 @@ import (
 )
 
 func New(cfg *config.Config, cmds ...tea.Cmd) appModel {
-	explorer := explorer.New()
+	explorer := explorer.New(cfg.Theory)
 	explorer.KeyMap = defaultKeyMap.Explorer
 
 	eval := eval.New(cfg.Theory)
+	eval.ChordStyle = cfg.File.Theory.ChordStyle
 
 	song := song.New(cfg)
 	song.KeyMap = defaultKeyMap.Song
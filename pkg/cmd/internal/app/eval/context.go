@@ -1,6 +1,7 @@
 package eval
 
 import (
+	"github.com/craiggwilson/songtool/pkg/setlist"
 	"github.com/craiggwilson/songtool/pkg/songio"
 	"github.com/craiggwilson/songtool/pkg/theory"
 )
@@ -9,4 +10,8 @@ type Context struct {
 	Theory *theory.Theory
 	Meta   *songio.Meta
 	Lines  []songio.Line
+
+	// Setlist is the setlist being played, if any, and SetlistIndex is the index of its current song.
+	Setlist      *setlist.Setlist
+	SetlistIndex int
 }
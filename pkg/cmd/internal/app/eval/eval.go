@@ -8,6 +8,8 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/craiggwilson/songtool/pkg/cmd/internal/app/message"
+	"github.com/craiggwilson/songtool/pkg/library"
+	"github.com/craiggwilson/songtool/pkg/setlist"
 	"github.com/craiggwilson/songtool/pkg/songio"
 	"github.com/craiggwilson/songtool/pkg/theory"
 	"github.com/craiggwilson/songtool/pkg/theory/interval"
@@ -23,16 +25,41 @@ func New(theory *theory.Theory) Model {
 
 type Model struct {
 	Context Context
+
+	// ChordStyle is the name of the chord style used to name the chords in songs. When empty, chords are shown as
+	// written.
+	ChordStyle string
 }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch tmsg := msg.(type) {
+	case message.ChangeChordStyleMsg:
+		m.ChordStyle = tmsg.Name
+		return m, m.styleSong()
 	case message.EvalMsg:
 		return m, run(m.Context, tmsg.Text)
 	case message.LoadDirectoryMsg:
 		return m, m.listFiles(tmsg.Path)
+	case message.OpenSetlistMsg:
+		return m, m.loadSetlist(tmsg.Path)
+	case message.MoveSetlistMsg:
+		if m.Context.Setlist == nil {
+			return m, message.UpdateStatusError(fmt.Errorf("no setlist loaded"))
+		}
+
+		index := m.Context.SetlistIndex + tmsg.By
+		if index < 0 || index >= len(m.Context.Setlist.Songs) {
+			return m, nil
+		}
+		return m, message.UpdateSetlist(m.Context.Setlist, index)
+	case message.UpdateSetlistMsg:
+		m.Context.Setlist = tmsg.Setlist
+		m.Context.SetlistIndex = tmsg.Index
+		return m, m.openSetlistSong(tmsg.Setlist, tmsg.Index)
 	case message.OpenSongMsg:
-		return m, m.openSong(tmsg.Path)
+		// Opening a song on its own leaves the setlist.
+		m.Context.Setlist = nil
+		return m, m.openSong(tmsg.Path, tmsg.Song)
 	case message.TransposeSongMsg:
 		return m, m.transposeSong(tmsg.Interval)
 	case message.UpdateSongMsg:
@@ -56,27 +83,18 @@ func (m Model) listFiles(path string) tea.Cmd {
 				continue
 			}
 
-			var file message.FileItem
-
 			//go func(i int) {
-			file.Path = filepath.Join(path, entries[i].Name())
-			f, err := os.Open(file.Path)
+			filePath := filepath.Join(path, entries[i].Name())
+			// Each song in a songbook is its own item.
+			songs, err := library.ReadFile(m.Context.Theory, filePath)
 			if err != nil {
-				log.Printf("failed opening %q: %v\n", file.Path, err)
-				f.Close()
+				log.Printf("failed getting meta for %q: %v\n", filePath, err)
 				continue
 			}
-			defer f.Close()
 
-			rdr := songio.ReadChordsOverLyrics(m.Context.Theory, m.Context.Theory, f)
-			meta, err := songio.ReadMeta(m.Context.Theory, rdr, false)
-			if err != nil {
-				log.Printf("failed getting meta for %q: %v\n", file.Path, err)
-				continue
+			for _, song := range songs {
+				files = append(files, message.FileItem{Song: song})
 			}
-
-			file.Meta = &meta
-			files = append(files, file)
 			//}(i)
 		}
 
@@ -84,7 +102,7 @@ func (m Model) listFiles(path string) tea.Cmd {
 	}
 }
 
-func (m Model) openSong(path string) tea.Cmd {
+func (m Model) openSong(path string, index int) tea.Cmd {
 	return func() tea.Msg {
 		var f *os.File
 		var err error
@@ -99,7 +117,23 @@ func (m Model) openSong(path string) tea.Cmd {
 		}
 		defer f.Close()
 
-		rdr := songio.ReadChordsOverLyrics(m.Context.Theory, m.Context.Theory, f)
+		songs := songio.ReadSongs(songio.ReadChordsOverLyrics(m.Context.Theory, m.Context.Theory, f))
+		song, ok := songs.Next()
+		for i := 0; ok && i < index; i++ {
+			song, ok = songs.Next()
+		}
+		if !ok {
+			if err := songs.Err(); err != nil {
+				return message.UpdateStatusError(err)()
+			}
+			return message.UpdateStatusError(fmt.Errorf("no song %d in %q", index+1, path))()
+		}
+
+		rdr, err := m.styleChords(songio.Expand(song))
+		if err != nil {
+			return message.UpdateStatusError(err)()
+		}
+
 		lines, err := songio.ReadAllLines(rdr)
 		if err != nil {
 			return message.UpdateStatusError(err)()
@@ -118,6 +152,47 @@ func (m Model) openSong(path string) tea.Cmd {
 	}
 }
 
+func (m Model) loadSetlist(path string) tea.Cmd {
+	return func() tea.Msg {
+		s, err := setlist.Load(path)
+		if err != nil {
+			return message.UpdateStatusError(err)()
+		}
+		if len(s.Songs) == 0 {
+			return message.UpdateStatusError(fmt.Errorf("setlist %q has no songs", path))()
+		}
+
+		return message.UpdateSetlist(s, 0)()
+	}
+}
+
+func (m Model) openSetlistSong(s *setlist.Setlist, index int) tea.Cmd {
+	return func() tea.Msg {
+		song, err := s.Open(m.Context.Theory, index)
+		if err != nil {
+			return message.UpdateStatusError(err)()
+		}
+
+		rdr, err := m.styleChords(songio.Expand(songio.FromLines(song.Lines)))
+		if err != nil {
+			return message.UpdateStatusError(err)()
+		}
+
+		lines, err := songio.ReadAllLines(rdr)
+		if err != nil {
+			return message.UpdateStatusError(err)()
+		}
+
+		meta, err := songio.ReadMeta(m.Context.Theory, songio.FromLines(lines), true)
+		if err != nil {
+			return message.UpdateStatusError(err)()
+		}
+
+		meta.Title = song.Title
+		return message.UpdateSong(meta, lines)()
+	}
+}
+
 func (m Model) transposeSong(by interval.Interval) tea.Cmd {
 	return func() tea.Msg {
 		transposed := songio.Transpose(m.Context.Theory, songio.FromLines(m.Context.Lines), by)
@@ -129,3 +204,37 @@ func (m Model) transposeSong(by interval.Interval) tea.Cmd {
 		return message.UpdateSong(meta, m.Context.Lines)()
 	}
 }
+
+func (m Model) styleChords(src songio.Reader) (songio.Reader, error) {
+	if len(m.ChordStyle) == 0 {
+		return src, nil
+	}
+
+	styled, err := m.Context.Theory.WithChordStyle(m.ChordStyle)
+	if err != nil {
+		return nil, err
+	}
+
+	return songio.NormalizeChords(styled, src), nil
+}
+
+func (m Model) styleSong() tea.Cmd {
+	return func() tea.Msg {
+		if m.Context.Meta == nil {
+			return nil
+		}
+
+		styled, err := m.styleChords(songio.FromLines(m.Context.Lines))
+		if err != nil {
+			return message.UpdateStatusError(err)()
+		}
+
+		meta, err := songio.ReadMeta(m.Context.Theory, styled, true)
+		if err != nil {
+			return message.UpdateStatusError(err)()
+		}
+
+		meta.Title = m.Context.Meta.Title
+		return message.UpdateSong(meta, m.Context.Lines)()
+	}
+}
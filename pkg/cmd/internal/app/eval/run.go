@@ -38,11 +38,35 @@ func run(ctx Context, text string) tea.Cmd {
 }
 
 var mainCmd struct {
+	Difficulty difficultyCmd `cmd:"" aliases:"d" help:"Show only the songs with a difficulty in a range."`
 	Enharmonic enharmonicCmd `cmd:"" aliases:"e" help:"Tranpose the song to it's enhmarmonic."`
+	Next       nextCmd       `cmd:"" aliases:"n" help:"Open the next song in the setlist."`
+	Prev       prevCmd       `cmd:"" aliases:"p" help:"Open the previous song in the setlist."`
 	Quit       quitCmd       `cmd:"" aliases:"q" help:"Quit the app."`
+	Setlist    setlistCmd    `cmd:"" help:"Play a setlist."`
+	Sort       sortCmd       `cmd:"" help:"Sort the songs in the explorer."`
+	Style      styleCmd      `cmd:"" aliases:"s" help:"Change the chord style used to name chords."`
 	Transpose  transposeCmd  `cmd:"" aliases:"t" help:"Transpose the current song."`
 }
 
+type difficultyCmd struct {
+	Min int `arg:"" optional:"" help:"The lowest difficulty to show; shows every song when not specified."`
+	Max int `arg:"" optional:"" help:"The highest difficulty to show; defaults to the lowest difficulty."`
+}
+
+func (cmd *difficultyCmd) Run(ctx Context, result *tea.Cmd) error {
+	max := cmd.Max
+	if max == 0 {
+		max = cmd.Min
+	}
+	if max < cmd.Min {
+		return fmt.Errorf("the highest difficulty must not be less than the lowest")
+	}
+
+	*result = message.FilterDifficulty(cmd.Min, max)
+	return nil
+}
+
 type enharmonicCmd struct{}
 
 func (cmd *enharmonicCmd) Run(ctx Context, result *tea.Cmd) error {
@@ -57,6 +81,28 @@ func (cmd *enharmonicCmd) Run(ctx Context, result *tea.Cmd) error {
 	return nil
 }
 
+type nextCmd struct{}
+
+func (cmd *nextCmd) Run(ctx Context, result *tea.Cmd) error {
+	if ctx.Setlist == nil {
+		return fmt.Errorf("no setlist loaded")
+	}
+
+	*result = message.MoveSetlist(1)
+	return nil
+}
+
+type prevCmd struct{}
+
+func (cmd *prevCmd) Run(ctx Context, result *tea.Cmd) error {
+	if ctx.Setlist == nil {
+		return fmt.Errorf("no setlist loaded")
+	}
+
+	*result = message.MoveSetlist(-1)
+	return nil
+}
+
 type quitCmd struct{}
 
 func (cmd *quitCmd) Run(ctx Context, result *tea.Cmd) error {
@@ -64,6 +110,37 @@ func (cmd *quitCmd) Run(ctx Context, result *tea.Cmd) error {
 	return nil
 }
 
+type setlistCmd struct {
+	Path string `arg:"" required:"" help:"The path to the setlist."`
+}
+
+func (cmd *setlistCmd) Run(ctx Context, result *tea.Cmd) error {
+	*result = tea.Batch(message.LoadSetlist(cmd.Path), message.EnterSongMode())
+	return nil
+}
+
+type sortCmd struct {
+	By string `arg:"" enum:"name,title,difficulty" default:"name" help:"What to sort by: name, title, or difficulty."`
+}
+
+func (cmd *sortCmd) Run(ctx Context, result *tea.Cmd) error {
+	*result = message.SortFiles(cmd.By)
+	return nil
+}
+
+type styleCmd struct {
+	Name string `arg:"<name>" required:""`
+}
+
+func (cmd *styleCmd) Run(ctx Context, result *tea.Cmd) error {
+	if _, ok := ctx.Theory.LookupChordStyle(cmd.Name); !ok {
+		return fmt.Errorf("unknown chord style %q", cmd.Name)
+	}
+
+	*result = message.ChangeChordStyle(cmd.Name)
+	return nil
+}
+
 type transposeCmd struct {
 	Arg string `arg:"<key or step>" required:""`
 }
@@ -2,18 +2,21 @@ package explorer
 
 import (
 	"fmt"
-	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/craiggwilson/songtool/pkg/cmd/internal/app/message"
+	"github.com/craiggwilson/songtool/pkg/library"
+	"github.com/craiggwilson/songtool/pkg/theory"
 	"github.com/sahilm/fuzzy"
 )
 
-func New() Model {
+func New(theory *theory.Theory) Model {
 	return Model{
+		theory: theory,
 		KeyMap: DefaultKeyMap(),
 		Styles: DefaultStyles(),
 	}
@@ -25,9 +28,13 @@ type Model struct {
 	Height int
 	Width  int
 
+	theory          *theory.Theory
 	leftColumnIdx   int
 	selectedItemIdx int
 	filter          string
+	minDifficulty   int
+	maxDifficulty   int
+	sortBy          string
 	filteredResults fuzzy.Matches
 	items           items
 	itemsPerColumn  int
@@ -42,6 +49,16 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	)
 
 	switch tmsg := msg.(type) {
+	case message.FilterDifficultyMsg:
+		m.minDifficulty = tmsg.Min
+		m.maxDifficulty = tmsg.Max
+		m.filterItems()
+		return m, message.Invalidate()
+	case message.SortFilesMsg:
+		m.sortBy = tmsg.By
+		m.sortItems()
+		m.filterItems()
+		return m, message.Invalidate()
 	case message.FilterFilesMsg:
 		m.filter = tmsg.Text
 		m.filterItems()
@@ -71,7 +88,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			if len(m.filteredResults) > 0 {
 				item := m.filteredResults[m.selectedItemIdx]
 				return m, tea.Batch(
-					message.LoadSong(m.items[item.Index].Path),
+					message.LoadSong(m.items[item.Index].Path, m.items[item.Index].Index),
 					message.EnterSongMode(),
 				)
 			}
@@ -108,12 +125,83 @@ func (m *Model) filterItems() {
 				Index: i,
 			}
 		}
+	} else if query, err := library.ParseQuery(m.theory, m.theory, m.filter); err == nil && query.HasFields() {
+		m.filteredResults = m.searchItems(query)
 	} else {
 		m.filteredResults = fuzzy.FindFrom(m.filter, m.items)
-		if m.selectedItemIdx > len(m.filteredResults) {
-			m.selectedItemIdx = len(m.filteredResults) - 1
+		if err == nil && !query.IsEmpty() {
+			m.filteredResults = append(m.filteredResults, m.searchLyrics(query)...)
+		}
+	}
+
+	if m.minDifficulty > 0 || m.maxDifficulty > 0 {
+		results := m.filteredResults[:0]
+		for _, result := range m.filteredResults {
+			difficulty := m.items[result.Index].Difficulty
+			if difficulty >= m.minDifficulty && (m.maxDifficulty == 0 || difficulty <= m.maxDifficulty) {
+				results = append(results, result)
+			}
+		}
+		m.filteredResults = results
+	}
+
+	if m.selectedItemIdx >= len(m.filteredResults) {
+		m.selectedItemIdx = max(len(m.filteredResults)-1, 0)
+	}
+}
+
+// searchItems ranks the items that match the query.
+func (m *Model) searchItems(query *library.Query) fuzzy.Matches {
+	var results fuzzy.Matches
+	for i := range m.items {
+		if score, ok := query.Match(&m.items[i].Song); ok {
+			results = append(results, fuzzy.Match{Index: i, Score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	return results
+}
+
+// searchLyrics finds the items that match the query by their lyrics or artist, leaving out those already found by
+// fuzzy matching their names.
+func (m *Model) searchLyrics(query *library.Query) fuzzy.Matches {
+	found := make(map[int]struct{}, len(m.filteredResults))
+	for _, result := range m.filteredResults {
+		found[result.Index] = struct{}{}
+	}
+
+	var results fuzzy.Matches
+	for _, result := range m.searchItems(query) {
+		if _, ok := found[result.Index]; !ok {
+			results = append(results, result)
 		}
 	}
+
+	return results
+}
+
+func (m *Model) sortItems() {
+	switch m.sortBy {
+	case "title":
+		sort.SliceStable(m.items, func(i, j int) bool {
+			return strings.ToLower(m.items[i].Name()) < strings.ToLower(m.items[j].Name())
+		})
+	case "difficulty":
+		sort.SliceStable(m.items, func(i, j int) bool {
+			return m.items[i].Difficulty < m.items[j].Difficulty
+		})
+	default:
+		sort.SliceStable(m.items, func(i, j int) bool {
+			if m.items[i].Path != m.items[j].Path {
+				return m.items[i].Path < m.items[j].Path
+			}
+			return m.items[i].Index < m.items[j].Index
+		})
+	}
 }
 
 func (m *Model) renderColumns() {
@@ -143,30 +231,23 @@ func (m *Model) renderColumn(columnIdx int) string {
 func (m *Model) updateItems(files []message.FileItem) tea.Cmd {
 	items := make([]item, len(files))
 	for i := range files {
-		items[i].Path = files[i].Path
-		title := filepath.Base(items[i].Path)
-		if ext := filepath.Ext(title); len(ext) > 0 {
-			title = strings.TrimSuffix(title, ext)
-		}
-		key := ""
-		if files[i].Meta != nil {
-			if len(files[i].Meta.Title) > 0 {
-				title = files[i].Meta.Title
-			}
+		items[i].Song = files[i].Song
 
-			if files[i].Meta.Key != nil {
-				key = files[i].Meta.Key.Name
-			}
+		key := ""
+		if len(items[i].Key) > 0 {
+			key = fmt.Sprintf("[%s]", m.Styles.KeyStyle.Render(items[i].Key))
 		}
 
-		if key != "" {
-			key = fmt.Sprintf("[%s]", m.Styles.KeyStyle.Render(key))
+		difficulty := ""
+		if items[i].Difficulty > 0 {
+			difficulty = fmt.Sprintf("%2d", items[i].Difficulty)
 		}
 
-		items[i].Text = lipgloss.JoinHorizontal(lipgloss.Top, fmt.Sprintf("%-5s", key), title)
+		items[i].Text = lipgloss.JoinHorizontal(lipgloss.Top, fmt.Sprintf("%-5s", key), m.Styles.DifficultyStyle.Render(fmt.Sprintf("%-3s", difficulty)), items[i].Name())
 	}
 
 	m.items = items
+	m.sortItems()
 	m.filterItems()
 
 	return message.Invalidate()
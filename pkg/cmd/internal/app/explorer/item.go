@@ -1,7 +1,9 @@
 package explorer
 
+import "github.com/craiggwilson/songtool/pkg/library"
+
 type item struct {
-	Path string
+	library.Song
 	Text string
 }
 
@@ -4,6 +4,7 @@ import "github.com/charmbracelet/lipgloss"
 
 func DefaultStyles() Styles {
 	return Styles{
+		DifficultyStyle:   lipgloss.NewStyle().Faint(true),
 		ItemStyle:         lipgloss.NewStyle(),
 		KeyStyle:          lipgloss.NewStyle(),
 		SelectedItemStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("3")),
@@ -12,6 +13,7 @@ func DefaultStyles() Styles {
 }
 
 type Styles struct {
+	DifficultyStyle   lipgloss.Style
 	ItemStyle         lipgloss.Style
 	KeyStyle          lipgloss.Style
 	SelectedItemStyle lipgloss.Style
@@ -29,12 +29,22 @@ type Model struct {
 	Width       int
 
 	Meta *songio.Meta
+	// SetlistIndex and SetlistCount are the position of the song in the setlist being played, if any.
+	SetlistIndex int
+	SetlistCount int
+	// Section is the index of the section on screen, used to show the key the section is in.
+	Section int
 }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch tmsg := msg.(type) {
 	case message.UpdateSongMsg:
 		m.Meta = &tmsg.Meta
+	case message.UpdateSetlistMsg:
+		m.SetlistIndex = tmsg.Index
+		m.SetlistCount = len(tmsg.Setlist.Songs)
+	case message.OpenSongMsg:
+		m.SetlistCount = 0
 	}
 
 	return m, nil
@@ -44,8 +54,25 @@ func (m Model) View() string {
 	title := "<no song>"
 	if m.Meta != nil {
 		title = m.Meta.Title
-		if m.Meta.Key != nil {
-			title += fmt.Sprintf(" [%s]", m.KeyStyle.Render(m.Meta.Key.Name))
+		if m.SetlistCount > 0 {
+			title = fmt.Sprintf("%d/%d %s", m.SetlistIndex+1, m.SetlistCount, title)
+		}
+		if len(m.Meta.Artist) > 0 {
+			title += " - " + m.Meta.Artist
+		}
+		if k := m.Meta.KeyForSection(m.Section); k != nil {
+			title += fmt.Sprintf(" [%s]", m.KeyStyle.Render(k.Name))
+		}
+
+		var details []string
+		if m.Meta.Tempo > 0 {
+			details = append(details, fmt.Sprintf("%d bpm", m.Meta.Tempo))
+		}
+		if m.Meta.Time != nil {
+			details = append(details, m.Meta.Time.String())
+		}
+		if len(details) > 0 {
+			title += " " + strings.Join(details, " ")
 		}
 	}
 
@@ -67,6 +67,7 @@ func (km *keyMap) FullHelp() [][]key.Binding {
 		{km.Command.Accept, km.Command.Clear},
 		{km.Global.Help, km.Global.Quit, km.Global.CommandMode, km.Global.Explorer, km.Global.Song},
 		{km.Song.Transpose, km.Song.TransposeDown1, km.Song.TransposeUp1},
+		{km.Song.NextSong, km.Song.PreviousSong},
 		{km.Song.Up, km.Song.Down, km.Song.PageUp, km.Song.PageDown, km.Song.HalfPageUp, km.Song.PageDown},
 	}
 }
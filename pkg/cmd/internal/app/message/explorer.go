@@ -2,9 +2,22 @@ package message
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/library"
 )
 
+// FilterDifficulty limits the files to those with a difficulty from min to max. A max of 0 means there is no upper
+// limit.
+func FilterDifficulty(min, max int) tea.Cmd {
+	return func() tea.Msg {
+		return FilterDifficultyMsg{Min: min, Max: max}
+	}
+}
+
+type FilterDifficultyMsg struct {
+	Min int
+	Max int
+}
+
 func FilterFiles(text string) tea.Cmd {
 	return func() tea.Msg {
 		return FilterFilesMsg{Text: text}
@@ -25,6 +38,17 @@ type LoadDirectoryMsg struct {
 	Path string
 }
 
+// SortFiles orders the files by the field, which is one of "name", "title", or "difficulty".
+func SortFiles(by string) tea.Cmd {
+	return func() tea.Msg {
+		return SortFilesMsg{By: by}
+	}
+}
+
+type SortFilesMsg struct {
+	By string
+}
+
 func UpdateFiles(files []FileItem) tea.Cmd {
 	return func() tea.Msg {
 		return UpdateFilesMsg{Files: files}
@@ -36,6 +60,5 @@ type UpdateFilesMsg struct {
 }
 
 type FileItem struct {
-	Path string
-	Meta *songio.Meta
+	Song library.Song
 }
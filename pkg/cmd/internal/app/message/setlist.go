@@ -0,0 +1,40 @@
+package message
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/craiggwilson/songtool/pkg/setlist"
+)
+
+// LoadSetlist opens the setlist at the path and its first song.
+func LoadSetlist(path string) tea.Cmd {
+	return func() tea.Msg {
+		return OpenSetlistMsg{Path: path}
+	}
+}
+
+type OpenSetlistMsg struct {
+	Path string
+}
+
+// MoveSetlist opens the song in the setlist that is by songs after the current one, or before it when by is negative.
+func MoveSetlist(by int) tea.Cmd {
+	return func() tea.Msg {
+		return MoveSetlistMsg{By: by}
+	}
+}
+
+type MoveSetlistMsg struct {
+	By int
+}
+
+// UpdateSetlist makes the song at the index in the setlist the current song.
+func UpdateSetlist(s *setlist.Setlist, index int) tea.Cmd {
+	return func() tea.Msg {
+		return UpdateSetlistMsg{Setlist: s, Index: index}
+	}
+}
+
+type UpdateSetlistMsg struct {
+	Setlist *setlist.Setlist
+	Index   int
+}
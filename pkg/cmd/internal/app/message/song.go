@@ -6,10 +6,21 @@ import (
 	"github.com/craiggwilson/songtool/pkg/theory/interval"
 )
 
-func LoadSong(path string) tea.Cmd {
+func ChangeChordStyle(name string) tea.Cmd {
+	return func() tea.Msg {
+		return ChangeChordStyleMsg{Name: name}
+	}
+}
+
+type ChangeChordStyleMsg struct {
+	Name string
+}
+
+// LoadSong opens the song at the index, starting at 0, in the file, which only matters for songbooks.
+func LoadSong(path string, song int) tea.Cmd {
 	return tea.Batch(
 		func() tea.Msg {
-			return OpenSongMsg{Path: path}
+			return OpenSongMsg{Path: path, Song: song}
 		},
 		EnterSongMode(),
 	)
@@ -17,6 +28,7 @@ func LoadSong(path string) tea.Cmd {
 
 type OpenSongMsg struct {
 	Path string
+	Song int
 }
 
 func TransposeSong(intval interval.Interval) tea.Cmd {
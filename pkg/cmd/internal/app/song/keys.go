@@ -11,6 +11,8 @@ func DefaultKeyMap() *KeyMap {
 		Transpose:      key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "transpose")),
 		TransposeDown1: key.NewBinding(key.WithKeys("h", "left"), key.WithHelp("←/h", "transpose down")),
 		TransposeUp1:   key.NewBinding(key.WithKeys("l", "right"), key.WithHelp("→/l", "transpose up")),
+		NextSong:       key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "next setlist song")),
+		PreviousSong:   key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "previous setlist song")),
 	}
 }
 
@@ -20,12 +22,16 @@ type KeyMap struct {
 	Transpose      key.Binding
 	TransposeDown1 key.Binding
 	TransposeUp1   key.Binding
+	NextSong       key.Binding
+	PreviousSong   key.Binding
 }
 
 func (km *KeyMap) SetEnabled(enabled bool) {
 	km.Transpose.SetEnabled(enabled)
 	km.TransposeDown1.SetEnabled(enabled)
 	km.TransposeUp1.SetEnabled(enabled)
+	km.NextSong.SetEnabled(enabled)
+	km.PreviousSong.SetEnabled(enabled)
 
 	km.KeyMap.Down.SetEnabled(enabled)
 	km.KeyMap.Up.SetEnabled(enabled)
@@ -22,6 +22,7 @@ func New(cfg *config.Config) Model {
 	songtext := songtext.New()
 	songtext.MaxColumns = cfg.Styles.MaxColumns
 	songtext.Styles.Chord = cfg.Styles.Chord.Style()
+	songtext.Styles.Directive = cfg.Styles.Directive.Style()
 	songtext.Styles.Lyrics = cfg.Styles.Lyrics.Style()
 	songtext.Styles.SectionName = cfg.Styles.SectionName.Style()
 
@@ -61,6 +62,10 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, message.Eval("transpose -- -1")
 		case key.Matches(tmsg, m.KeyMap.TransposeUp1):
 			return m, message.Eval("transpose 1")
+		case key.Matches(tmsg, m.KeyMap.NextSong):
+			return m, message.Eval("next")
+		case key.Matches(tmsg, m.KeyMap.PreviousSong):
+			return m, message.Eval("prev")
 		}
 	case message.InvalidateMsg:
 		m.songtext.KeyMap = m.KeyMap.KeyMap
@@ -73,14 +78,15 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.songtext.Height = m.Height - headerHeight - footerHeight
 	}
 
-	m.footer.ScrollPercent = m.songtext.ScrollPercent()
-
 	m.header, cmd = m.header.Update(msg)
 	cmds = append(cmds, cmd)
 
 	m.songtext, cmd = m.songtext.Update(msg)
 	cmds = append(cmds, cmd)
 
+	m.footer.ScrollPercent = m.songtext.ScrollPercent()
+	m.header.Section = m.songtext.CurrentSection()
+
 	m.footer, cmd = m.footer.Update(msg)
 	cmds = append(cmds, cmd)
 
@@ -30,6 +30,31 @@ type Model struct {
 	Lines []songio.Line
 
 	viewport viewport.Model
+	// sectionOffsets are the lines in the content where each section in the first column starts.
+	sectionOffsets []sectionOffset
+}
+
+type sectionOffset struct {
+	index int
+	line  int
+}
+
+// CurrentSection is the index of the section at the top of the first column on screen, or -1 when there are no
+// sections.
+func (m Model) CurrentSection() int {
+	current := -1
+	for _, so := range m.sectionOffsets {
+		if so.line > m.viewport.YOffset {
+			break
+		}
+		current = so.index
+	}
+
+	if current == -1 && len(m.sectionOffsets) > 0 {
+		current = m.sectionOffsets[0].index
+	}
+
+	return current
 }
 
 func (m Model) ScrollPercent() float64 {
@@ -40,7 +65,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch tmsg := msg.(type) {
 	case message.UpdateSongMsg:
 		m.Lines = tmsg.Lines
-		m.viewport.SetContent(m.contentView())
+		content, sectionOffsets := m.contentView()
+		m.sectionOffsets = sectionOffsets
+		m.viewport.SetContent(content)
 		return m, nil
 	case message.InvalidateMsg:
 		m.viewport.Height = m.Height
@@ -56,9 +83,9 @@ func (m Model) View() string {
 	return m.viewport.View()
 }
 
-func (m Model) contentView() string {
+func (m Model) contentView() (string, []sectionOffset) {
 	if len(m.Lines) == 0 {
-		return ""
+		return "", nil
 	}
 
 	sections := m.buildSections()
@@ -78,12 +105,19 @@ func (m Model) contentView() string {
 	numCols := max(1, min(m.Width/maxSectionWidth, m.MaxColumns))
 	colStyle.Width(maxSectionWidth)
 
+	var sectionOffsets []sectionOffset
 	renderedColumns := make([]string, numCols)
 	for i := 0; i < len(renderedSections); i += numCols {
 		for j := 0; j < numCols && i+j < len(renderedSections); j++ {
 			if i >= numCols {
 				renderedColumns[j] += "\n\n"
 			}
+			if j == 0 {
+				sectionOffsets = append(sectionOffsets, sectionOffset{
+					index: i,
+					line:  strings.Count(renderedColumns[j], "\n"),
+				})
+			}
 			renderedColumns[j] += renderedSections[i+j]
 		}
 	}
@@ -92,7 +126,7 @@ func (m Model) contentView() string {
 		renderedColumns[i] = colStyle.Render(renderedColumns[i])
 	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, renderedColumns...)
+	return lipgloss.JoinHorizontal(lipgloss.Top, renderedColumns...), sectionOffsets
 }
 
 func (m Model) buildSections() []section {
@@ -109,19 +143,12 @@ func (m Model) buildSections() []section {
 		case *songio.TextLine:
 			currentSection.lines = append(currentSection.lines, m.Styles.Lyrics.Render(tl.Text))
 		case *songio.ChordLine:
-			row := ""
-			currentOffset := 0
-			for _, chordOffset := range tl.Chords {
-				offsetDiff := chordOffset.Offset - currentOffset
-				if offsetDiff > 0 {
-					row += strings.Repeat(" ", offsetDiff)
-					currentOffset += offsetDiff
+			row := songio.RenderChordLine(tl, func(seg songio.Segment) string {
+				if seg.Marker != nil {
+					return m.Styles.Directive.Render(seg.Text)
 				}
-
-				chordName := chordOffset.Chord.Name
-				row += m.Styles.Chord.Render(chordName)
-				currentOffset += len(chordName)
-			}
+				return m.Styles.Chord.Render(seg.Text)
+			})
 
 			currentSection.lines = append(currentSection.lines, row)
 		case *songio.EmptyLine:
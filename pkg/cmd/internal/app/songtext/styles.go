@@ -4,6 +4,7 @@ import "github.com/charmbracelet/lipgloss"
 
 type Styles struct {
 	Chord       lipgloss.Style
+	Directive   lipgloss.Style
 	Lyrics      lipgloss.Style
 	SectionName lipgloss.Style
 }
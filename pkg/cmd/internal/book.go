@@ -0,0 +1,137 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/craiggwilson/songtool/pkg/book"
+	"github.com/craiggwilson/songtool/pkg/cmd/internal/config"
+	"github.com/craiggwilson/songtool/pkg/setlist"
+	"github.com/craiggwilson/songtool/pkg/songio"
+)
+
+type BookCmd struct {
+	Format    string `name:"format" short:"f" enum:"text,markdown,html" default:"text" help:"The format of the book: text, markdown, or html; defaults to 'text'."`
+	Title     string `name:"title" help:"The title of the book; defaults to the title of the setlist or the name of the directory."`
+	Transpose int    `name:"transpose" short:"t" help:"The number of half steps to transpose the songs in a directory; can be negative. The songs in a setlist are transposed as the setlist says."`
+	Style     string `name:"style" help:"The chord style used to name the chords, such as 'pop', 'jazz', or 'classical'; defaults to the chordStyle in the config."`
+	Output    string `name:"output" short:"o" type:"path" help:"The file to write the book to; defaults to stdout."`
+	NoCache   bool   `name:"no-cache" help:"Reads every song in a directory rather than using the library cache."`
+
+	Path string `arg:"" type:"path" help:"A directory of songs, which are ordered by title, or a setlist."`
+}
+
+func (cmd *BookCmd) Run(cfg *config.Config) error {
+	s, err := cmd.setlist(cfg)
+	if err != nil {
+		return err
+	}
+
+	b := book.Book{Title: cmd.Title, CSS: cfg.Styles.CSS()}
+	if len(b.Title) == 0 {
+		b.Title = s.Title
+	}
+
+	for i := range s.Songs {
+		song, err := cmd.song(cfg, s, i)
+		if err != nil {
+			return err
+		}
+		b.Songs = append(b.Songs, song)
+	}
+
+	var w io.Writer = os.Stdout
+	if len(cmd.Output) > 0 {
+		f, err := os.Create(cmd.Output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return book.Write(cfg.Theory, &b, book.Format(cmd.Format), w)
+}
+
+// setlist returns the setlist at the path, or a setlist of the songs in the directory at the path, ordered by title.
+func (cmd *BookCmd) setlist(cfg *config.Config) (*setlist.Setlist, error) {
+	fi, err := os.Stat(cmd.Path)
+	if err != nil {
+		return nil, fmt.Errorf("could not stat %s: %w", cmd.Path, err)
+	}
+
+	if !fi.IsDir() {
+		if cmd.Transpose != 0 {
+			return nil, fmt.Errorf("transpose cannot be used with a setlist; set the key or transpose of its songs instead")
+		}
+		return setlist.Load(cmd.Path)
+	}
+
+	songs, err := scanLibrary(cfg, cmd.Path, cmd.NoCache)
+	if err != nil {
+		return nil, err
+	}
+	sortSongs(songs, "title", false)
+
+	abs, err := filepath.Abs(cmd.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &setlist.Setlist{Title: filepath.Base(abs), Dir: abs}
+	for _, song := range songs {
+		// The capo is kept, so that the chords are printed in the shapes they are written in.
+		entry := setlist.Entry{Path: song.Path, Capo: song.Capo}
+		if song.Count > 1 {
+			entry.Song = strconv.Itoa(song.Index + 1)
+		}
+		// Songs without a key are printed as they are written.
+		if len(song.Key) > 0 {
+			entry.Transpose = cmd.Transpose
+		}
+		s.Songs = append(s.Songs, entry)
+	}
+
+	return s, nil
+}
+
+func (cmd *BookCmd) song(cfg *config.Config, s *setlist.Setlist, index int) (book.Song, error) {
+	song, err := s.Open(cfg.Theory, index)
+	if err != nil {
+		return book.Song{}, err
+	}
+
+	styled, err := styleChords(cfg, songio.Expand(songio.FromLines(song.Lines)), cmd.Style)
+	if err != nil {
+		return book.Song{}, err
+	}
+
+	lines, err := songio.ReadAllLines(styled)
+	if err != nil {
+		return book.Song{}, err
+	}
+
+	meta, err := songio.ReadMeta(cfg.Theory, songio.FromLines(lines), false)
+	if err != nil {
+		return book.Song{}, err
+	}
+
+	bs := book.Song{
+		Title:  song.Title,
+		Artist: meta.Artist,
+		Capo:   song.Capo,
+		Tempo:  meta.Tempo,
+		Lines:  lines,
+	}
+	if song.Key != nil {
+		bs.Key = song.Key.Name
+	}
+	if meta.Time != nil {
+		bs.Time = meta.Time.String()
+	}
+
+	return bs, nil
+}
@@ -3,7 +3,6 @@ package internal
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/craiggwilson/songtool/pkg/cmd/internal/config"
 	"github.com/craiggwilson/songtool/pkg/songio"
@@ -12,22 +11,42 @@ import (
 type CatCmd struct {
 	songCmd
 
-	NoChords bool  `name:"no-chords" help:"Hides chords from the output."`
-	JSON     bool  `name:"json" xor:"json" help:"Prints the output as JSON."`
-	Color    color `name:"color" xor:"json" default:"${color}" negatable:"" help:"Indicates whether to use color"`
+	Expand    bool          `name:"expand" help:"Prints the song as performed, following the order directive and repeating sections that are named again without content."`
+	Sections  []string      `name:"section" short:"s" help:"Prints only the sections whose names match, such as 'Chorus' or 'Verse*'; may be repeated."`
+	Excludes  []string      `name:"exclude-section" help:"Omits the sections whose names match, such as 'Bridge' or 'Verse*'; may be repeated."`
+	NoChords  bool          `name:"no-chords" help:"Hides chords from the output."`
+	Simplify  simplifyLevel `name:"simplify" help:"Simplifies the chords for beginners: 1 drops extensions, 2 also drops sevenths, slash basses, and suspensions, and 3 also uses only major and minor chords. Defaults to 2 when no level is given."`
+	Style     string        `name:"style" help:"The chord style used to name the chords, such as 'pop', 'jazz', or 'classical'; defaults to the chordStyle in the config."`
+	JSON      bool          `name:"json" xor:"json" help:"Prints the output as JSON."`
+	Positions bool          `name:"positions" help:"Includes the position of each line in the source when printing JSON."`
+	Color     color         `name:"color" default:"${color}" negatable:"" help:"Indicates whether to use color; ignored when printing JSON."`
 }
 
 func (cmd *CatCmd) Run(cfg *config.Config) error {
 	defer cmd.ensurePath().Close()
 
-	song := cmd.openSong(cfg)
+	song := songio.Reader(cmd.openSong(cfg))
+	if cmd.Expand {
+		song = songio.Expand(song)
+	}
+	if len(cmd.Sections) > 0 || len(cmd.Excludes) > 0 {
+		song = songio.FilterSections(song, cmd.Sections, cmd.Excludes)
+	}
+	if cmd.Simplify > 0 {
+		song = songio.Simplify(cfg.Theory, song, int(cmd.Simplify))
+	}
+
+	song, err := styleChords(cfg, song, cmd.Style)
+	if err != nil {
+		return err
+	}
 
 	if cmd.NoChords {
 		song = songio.RemoveChords(song)
 	}
 
 	if cmd.JSON {
-		return cmd.printSongJSON(song)
+		return cmd.printSongJSON(song, cmd.Positions)
 	}
 
 	return cmd.printSong(cfg, song)
@@ -36,53 +55,51 @@ func (cmd *CatCmd) Run(cfg *config.Config) error {
 func (cmd *songCmd) printSong(cfg *config.Config, song songio.Reader) error {
 	for line, ok := song.Next(); ok; line, ok = song.Next() {
 		switch tl := line.(type) {
-		case *songio.TitleDirectiveLine:
-			fmt.Println(cfg.Styles.Directive.Render(fmt.Sprintf("#title=%s", tl.Title)))
 		case *songio.KeyDirectiveLine:
 			fmt.Println(cfg.Styles.Directive.Render(fmt.Sprintf("#key=%s", cfg.Styles.Chord.Render(tl.Key.Name))))
-		case *songio.UnknownDirectiveLine:
-			fmt.Printf(cfg.Styles.Directive.Render(fmt.Sprintf("#%s", tl.Name)))
-			if len(tl.Value) > 0 {
-				fmt.Printf(cfg.Styles.Directive.Render(fmt.Sprintf("=%s", tl.Value)))
-			}
-			fmt.Println()
 		case *songio.SectionStartDirectiveLine:
 			fmt.Println(cfg.Styles.SectionName.Render(tl.Name))
 		case *songio.SectionEndDirectiveLine:
 			fmt.Println()
+		case *songio.SongBreakLine:
+			fmt.Println(cfg.Styles.Directive.Render("---"))
 		case *songio.TextLine:
 			fmt.Println(cfg.Styles.Lyrics.Render(tl.Text))
 		case *songio.ChordLine:
-			row := ""
-			currentOffset := 0
-			for _, chordOffset := range tl.Chords {
-				offsetDiff := chordOffset.Offset - currentOffset
-				if offsetDiff > 0 {
-					row += strings.Repeat(" ", offsetDiff)
-					currentOffset += offsetDiff
+			row := songio.RenderChordLine(tl, func(seg songio.Segment) string {
+				if seg.Marker != nil {
+					return cfg.Styles.Directive.Render(seg.Text)
 				}
-
-				chordName := chordOffset.Chord.Name
-				row += cfg.Styles.Chord.Render(chordName)
-				currentOffset += len(chordName)
-			}
+				return cfg.Styles.Chord.Render(seg.Text)
+			})
 
 			fmt.Println(row)
 		default:
+			if name, value, ok := songio.Directive(line); ok {
+				fmt.Print(cfg.Styles.Directive.Render(fmt.Sprintf("#%s", name)))
+				if len(value) > 0 {
+					fmt.Print(cfg.Styles.Directive.Render(fmt.Sprintf("=%s", value)))
+				}
+			}
 			fmt.Println()
 		}
 	}
 
-	return nil
+	return song.Err()
 }
 
-func (cmd *songCmd) printSongJSON(song songio.Reader) error {
+func (cmd *songCmd) printSongJSON(song songio.Reader, positions bool) error {
 	lines, err := songio.ReadAllLines(song)
 	if err != nil {
 		return err
 	}
 
-	out, err := json.MarshalIndent(lines, "", "  ")
+	var v interface{} = lines
+	if positions {
+		v = songio.WithPositions(lines)
+	}
+
+	out, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
 		return err
 	}
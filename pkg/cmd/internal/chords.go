@@ -1,5 +1,6 @@
 package internal
 
 type ChordsCmd struct {
-	Parse ChordsParseCmd `cmd:"" help:"Parse a chord for validity and proper naming."`
+	Parse     ChordsParseCmd     `cmd:"" help:"Parse a chord for validity and proper naming."`
+	VoiceLead ChordsVoiceLeadCmd `cmd:"" name:"voicelead" help:"Chooses voicings for a progression which minimize the movement between chords."`
 }
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/craiggwilson/songtool/pkg/cmd/internal/config"
 	"github.com/craiggwilson/songtool/pkg/theory/chord"
@@ -33,9 +34,35 @@ func (cmd *ChordsParseCmd) print(cfg *config.Config, c chord.Named) error {
 		fmt.Println("Normalized Name:", formalName)
 	}
 	fmt.Println("Intervals:", c.Parsed.Chord.Intervals())
+	fmt.Println("Inversion:", describeInversion(c.Inversion()))
+
+	alternatives := cfg.Theory.AlternativeChords(c.Chord)
+	if len(alternatives) > 0 {
+		names := make([]string, 0, len(alternatives))
+		for _, alt := range alternatives {
+			names = append(names, cfg.Theory.NameChord(alt))
+		}
+		fmt.Println("Alternative names:", strings.Join(names, ", "))
+	}
+
 	return nil
 }
 
 func (cmd *ChordsParseCmd) printJSON(cfg *config.Config, c chord.Named) error {
-	return printJSON(c)
+	alternatives := cfg.Theory.AlternativeChords(c.Chord)
+	names := make([]string, 0, len(alternatives))
+	for _, alt := range alternatives {
+		names = append(names, cfg.Theory.NameChord(alt))
+	}
+
+	return printJSON(chordSurrogate{
+		Name:              c.Name,
+		Root:              c.Root(),
+		Suffix:            c.Suffix,
+		BaseNoteDelimiter: c.BaseNoteDelimiter,
+		Base:              c.Base(),
+		Intervals:         c.Intervals(),
+		Inversion:         c.Inversion(),
+		AlternativeNames:  names,
+	})
 }
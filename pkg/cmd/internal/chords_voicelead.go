@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/craiggwilson/songtool/pkg/cmd/internal/config"
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/voicing"
+)
+
+type ChordsVoiceLeadCmd struct {
+	JSON bool   `name:"json" help:"Prints the output as JSON."`
+	Low  string `name:"low" default:"C3" help:"The lowest note a voicing may use, including the octave."`
+	High string `name:"high" default:"G5" help:"The highest note a voicing may use, including the octave."`
+	Song string `name:"song" type:"existingfile" xor:"chords" help:"The path to a song whose chord sequence will be voiced."`
+
+	Names []string `arg:"<names>" optional:"" xor:"chords" help:"The names of the chords in the progression."`
+}
+
+func (cmd *ChordsVoiceLeadCmd) Run(cfg *config.Config) error {
+	low, err := voicing.ParseVoice(cfg.Theory, cmd.Low)
+	if err != nil {
+		return fmt.Errorf("invalid low: %w", err)
+	}
+
+	high, err := voicing.ParseVoice(cfg.Theory, cmd.High)
+	if err != nil {
+		return fmt.Errorf("invalid high: %w", err)
+	}
+
+	var chords []chord.Named
+	if len(cmd.Song) > 0 {
+		chords, err = cmd.readSongChords(cfg)
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, name := range cmd.Names {
+			c, err := cfg.Theory.ParseChord(name)
+			if err != nil {
+				return err
+			}
+			chords = append(chords, c)
+		}
+	}
+
+	if len(chords) == 0 {
+		return fmt.Errorf("no chords to voice")
+	}
+
+	unnamed := make([]chord.Chord, 0, len(chords))
+	for _, c := range chords {
+		unnamed = append(unnamed, c.Chord)
+	}
+
+	voicings, err := voicing.Lead(unnamed, voicing.Range{Low: low, High: high})
+	if err != nil {
+		return err
+	}
+
+	if cmd.JSON {
+		return cmd.printJSON(cfg, chords, voicings)
+	}
+
+	return cmd.print(cfg, chords, voicings)
+}
+
+func (cmd *ChordsVoiceLeadCmd) print(cfg *config.Config, chords []chord.Named, voicings []voicing.Voicing) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for i, v := range voicings {
+		names := make([]string, 0, len(v.Voices))
+		for _, voice := range v.Voices {
+			names = append(names, voice.Name(cfg.Theory))
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", chords[i].Name, strings.Join(names, " "), describeInversion(chord.Inversion(v.Inversion)))
+	}
+
+	return tw.Flush()
+}
+
+func (cmd *ChordsVoiceLeadCmd) printJSON(cfg *config.Config, chords []chord.Named, voicings []voicing.Voicing) error {
+	surs := make([]voicingSurrogate, 0, len(voicings))
+	for i, v := range voicings {
+		voices := make([]string, 0, len(v.Voices))
+		for _, voice := range v.Voices {
+			voices = append(voices, voice.Name(cfg.Theory))
+		}
+
+		surs = append(surs, voicingSurrogate{
+			Name:      chords[i].Name,
+			Voices:    voices,
+			Inversion: v.Inversion,
+		})
+	}
+
+	return printJSON(surs)
+}
+
+func (cmd *ChordsVoiceLeadCmd) readSongChords(cfg *config.Config) ([]chord.Named, error) {
+	f, err := os.Open(cmd.Song)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var chords []chord.Named
+	song := songio.ReadChordsOverLyrics(cfg.Theory, cfg.Theory, f)
+	for line, ok := song.Next(); ok; line, ok = song.Next() {
+		if cl, ok := line.(*songio.ChordLine); ok {
+			for _, chordOffset := range cl.Chords {
+				chords = append(chords, chordOffset.Chord)
+			}
+		}
+	}
+
+	return chords, song.Err()
+}
@@ -13,9 +13,17 @@ type Config struct {
 }
 
 type File struct {
-	Edit   Edit              `json:"edit"`
-	Styles Styles            `json:"styles"`
-	Theory theory.ConfigBase `json:"theory"`
+	Analyze Analyze           `json:"analyze"`
+	Edit    Edit              `json:"edit"`
+	Lint    Lint              `json:"lint"`
+	Styles  Styles            `json:"styles"`
+	Theory  theory.ConfigBase `json:"theory"`
+}
+
+type Analyze struct {
+	// Progressions are added to the built-in progressions, keyed by name and written as Roman numerals, such as
+	// "I V vi IV". A built-in progression is removed by giving it an empty value.
+	Progressions map[string]string `json:"progressions,omitempty"`
 }
 
 type Edit struct {
@@ -23,6 +31,10 @@ type Edit struct {
 	Args    []string `json:"args,omitempty"`
 }
 
+type Lint struct {
+	AllowedSections []string `json:"allowedSections,omitempty"`
+}
+
 type Styles struct {
 	MaxColumns int `json:"maxColumns,omitempty"`
 
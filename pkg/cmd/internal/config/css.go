@@ -0,0 +1,143 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ansiColors are the CSS colors of the 16 standard terminal colors.
+var ansiColors = [16]string{
+	"#000000", "#800000", "#008000", "#808000", "#000080", "#800080", "#008080", "#c0c0c0",
+	"#808080", "#ff0000", "#00ff00", "#ffff00", "#0000ff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// CSS translates the styles into CSS rules for the HTML written by songio.WriteHTML. The light colors are used by
+// default and the dark colors when the reader prefers a dark color scheme.
+func (s *Styles) CSS() string {
+	rules := []struct {
+		selector string
+		style    *Style
+	}{
+		{".song .title", &s.Title},
+		{".song .section-name", &s.SectionName},
+		{".song .chord", &s.Chord},
+		{".song .lyrics", &s.Lyrics},
+		{".song .directives, .song .comment, .song .marker, .song .song-break", &s.Directive},
+	}
+
+	var light, dark strings.Builder
+	for _, r := range rules {
+		if decls := r.style.css(false); len(decls) > 0 {
+			fmt.Fprintf(&light, "%s { %s }\n", r.selector, decls)
+		}
+
+		if r.style.Foreground.differs() || r.style.Background.differs() {
+			if colors := r.style.colorCSS(true); len(colors) > 0 {
+				fmt.Fprintf(&dark, "  %s { %s }\n", r.selector, colors)
+			}
+		}
+	}
+
+	if dark.Len() > 0 {
+		fmt.Fprintf(&light, "@media (prefers-color-scheme: dark) {\n%s}\n", dark.String())
+	}
+
+	return light.String()
+}
+
+func (s *Style) css(dark bool) string {
+	var decls []string
+	if colors := s.colorCSS(dark); len(colors) > 0 {
+		decls = append(decls, colors)
+	}
+	if s.Bold {
+		decls = append(decls, "font-weight: bold;")
+	}
+	if s.Italic {
+		decls = append(decls, "font-style: italic;")
+	}
+	if s.Underline {
+		decls = append(decls, "text-decoration: underline;")
+	}
+
+	return strings.Join(decls, " ")
+}
+
+func (s *Style) colorCSS(dark bool) string {
+	var decls []string
+	if c := s.Foreground.css(dark); len(c) > 0 {
+		decls = append(decls, "color: "+c+";")
+	}
+	if c := s.Background.css(dark); len(c) > 0 {
+		decls = append(decls, "background-color: "+c+";")
+	}
+
+	return strings.Join(decls, " ")
+}
+
+// css returns the CSS color for a light or dark background, falling back to the other when only one is set, as the
+// terminal does.
+func (csc Color) css(dark bool) string {
+	value := csc.Light
+	if dark || len(value) == 0 {
+		value = csc.Dark
+	}
+	if len(value) == 0 {
+		value = csc.Light
+	}
+
+	return cssColor(value)
+}
+
+// differs indicates whether the color is different on a dark background.
+func (csc Color) differs() bool {
+	return len(csc.Light) > 0 && len(csc.Dark) > 0 && csc.Light != csc.Dark
+}
+
+// cssColor translates a terminal color, which is either an ANSI color number or a hex color such as #f80 or #ff8800,
+// into a CSS color. Other values are dropped, so that they can't break out of the stylesheet.
+func cssColor(value string) string {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		if isHexColor(value) {
+			return value
+		}
+		return ""
+	}
+
+	switch {
+	case n < 0 || n > 255:
+		return ""
+	case n < 16:
+		return ansiColors[n]
+	case n < 232:
+		// The 6x6x6 color cube.
+		n -= 16
+		level := func(v int) int {
+			if v == 0 {
+				return 0
+			}
+			return 55 + v*40
+		}
+		return fmt.Sprintf("#%02x%02x%02x", level(n/36), level(n/6%6), level(n%6))
+	default:
+		// The grayscale ramp.
+		gray := 8 + (n-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	}
+}
+
+func isHexColor(value string) bool {
+	if (len(value) != 4 && len(value) != 7) || value[0] != '#' {
+		return false
+	}
+
+	for _, r := range value[1:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -39,7 +39,10 @@ func Load(path string) (*Config, error) {
 		return nil, fmt.Errorf("reading config file: %w", err)
 	}
 
-	theoryCfg := theory.NewConfig(cfgFile.Theory)
+	theoryCfg, err := theory.NewConfig(cfgFile.Theory)
+	if err != nil {
+		return nil, fmt.Errorf("invalid theory config: %w", err)
+	}
 
 	return &Config{
 		File:   cfgFile,
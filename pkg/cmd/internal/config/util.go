@@ -1,5 +1,11 @@
 package config
 
+import (
+	"path/filepath"
+
+	"github.com/kirsle/configdir"
+)
+
 func firstNonEmptyString(ss ...string) string {
 	for _, s := range ss {
 		if s != "" {
@@ -8,3 +14,8 @@ func firstNonEmptyString(ss ...string) string {
 	}
 	return ""
 }
+
+// CachePath returns the path of the named file in songtool's cache directory.
+func CachePath(name string) string {
+	return filepath.Join(configdir.LocalCache("songtool"), name)
+}
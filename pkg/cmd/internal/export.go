@@ -0,0 +1,5 @@
+package internal
+
+type ExportCmd struct {
+	HTML ExportHTMLCmd `cmd:"" name:"html" help:"Exports the song as an HTML page."`
+}
@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"html/template"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/craiggwilson/songtool/pkg/cmd/internal/config"
+	"github.com/craiggwilson/songtool/pkg/songio"
+)
+
+var exportHTMLTemplate = template.Must(template.New("export").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+{{.CSS}}</style>
+</head>
+<body>
+{{.Song}}</body>
+</html>
+`))
+
+type ExportHTMLCmd struct {
+	songCmd
+
+	Expand bool   `name:"expand" help:"Exports the song as performed, following the order directive and repeating sections that are named again without content."`
+	Style  string `name:"style" help:"The chord style used to name the chords, such as 'pop', 'jazz', or 'classical'; defaults to the chordStyle in the config."`
+	Output string `name:"output" short:"o" type:"path" help:"The file to write the page to; defaults to stdout."`
+}
+
+func (cmd *ExportHTMLCmd) Run(cfg *config.Config) error {
+	defer cmd.ensurePath().Close()
+
+	song := songio.Reader(cmd.openSong(cfg))
+	if cmd.Expand {
+		song = songio.Expand(song)
+	}
+
+	song, err := styleChords(cfg, song, cmd.Style)
+	if err != nil {
+		return err
+	}
+
+	lines, err := songio.ReadAllLines(song)
+	if err != nil {
+		return err
+	}
+
+	meta, err := songio.ReadMeta(cfg.Theory, songio.FromLines(lines), false)
+	if err != nil {
+		return err
+	}
+
+	var body strings.Builder
+	if _, err := songio.WriteHTML(cfg.Theory, songio.FromLines(lines), &body); err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if len(cmd.Output) > 0 {
+		f, err := os.Create(cmd.Output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return exportHTMLTemplate.Execute(w, struct {
+		Title string
+		CSS   template.CSS
+		Song  template.HTML
+	}{
+		Title: meta.Title,
+		CSS:   template.CSS(songio.HTMLStylesheet + cfg.Styles.CSS()),
+		Song:  template.HTML(body.String()),
+	})
+}
@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/craiggwilson/songtool/pkg/cmd/internal/config"
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
+)
+
+type FmtCmd struct {
+	Check bool   `name:"check" xor:"mode" help:"Lists the songs that are not formatted and fails if there are any, without changing them."`
+	Write bool   `name:"write" short:"w" xor:"mode" help:"Writes the formatted song back to its file instead of printing it."`
+	Style string `name:"style" help:"The chord style used to name the chords, such as 'pop', 'jazz', or 'classical'; defaults to the chordStyle in the config."`
+
+	InferKeys bool `name:"infer-keys" help:"Inserts key directives for the key changes detected in songs without key changes of their own."`
+
+	Paths []string `arg:"" optional:"" type:"existingfile" help:"The paths to the songs; reads from stdin when none are provided."`
+}
+
+func (cmd *FmtCmd) Run(cfg *config.Config) error {
+	if len(cmd.Paths) == 0 {
+		if cmd.Write {
+			return fmt.Errorf("cannot use --write when reading from stdin")
+		}
+
+		src, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		formatted, err := cmd.format(cfg, src)
+		if err != nil {
+			return err
+		}
+
+		if cmd.Check {
+			if !bytes.Equal(src, formatted) {
+				return fmt.Errorf("<stdin> is not formatted")
+			}
+			return nil
+		}
+
+		_, err = os.Stdout.Write(formatted)
+		return err
+	}
+
+	unformatted := 0
+	for _, path := range cmd.Paths {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		formatted, err := cmd.format(cfg, src)
+		if err != nil {
+			return fmt.Errorf("formatting %q: %w", path, err)
+		}
+
+		switch {
+		case cmd.Check:
+			if !bytes.Equal(src, formatted) {
+				fmt.Println(path)
+				unformatted++
+			}
+		case cmd.Write:
+			if !bytes.Equal(src, formatted) {
+				if err := os.WriteFile(path, formatted, 0644); err != nil {
+					return err
+				}
+			}
+		default:
+			if _, err := os.Stdout.Write(formatted); err != nil {
+				return err
+			}
+		}
+	}
+
+	if unformatted > 0 {
+		return fmt.Errorf("%d of %d songs are not formatted", unformatted, len(cmd.Paths))
+	}
+
+	return nil
+}
+
+func (cmd *FmtCmd) format(cfg *config.Config, src []byte) ([]byte, error) {
+	theory := cfg.Theory
+	if len(cmd.Style) > 0 {
+		styled, err := cfg.Theory.WithChordStyle(cmd.Style)
+		if err != nil {
+			return nil, fmt.Errorf("invalid style: %w", err)
+		}
+		theory = styled
+	}
+
+	song := songio.ReadChordsOverLyrics(theory, theory, bytes.NewReader(src))
+	lines, err := songio.ReadAllLines(songio.NormalizeChords(theory, song))
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.InferKeys {
+		lines, err = inferKeys(cfg, lines)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	formatter := songio.Format(songio.FromLines(lines))
+
+	var buf bytes.Buffer
+	if _, err := songio.WriteChordsOverLyrics(theory, formatter, &buf); err != nil {
+		return nil, err
+	}
+
+	if err := formatter.Err(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func inferKeys(cfg *config.Config, lines []songio.Line) ([]songio.Line, error) {
+	keys := 0
+	for _, line := range lines {
+		if _, ok := line.(*songio.KeyDirectiveLine); ok {
+			keys++
+		}
+	}
+
+	if keys > 1 {
+		return lines, nil
+	}
+
+	modulations, err := detectModulations(cfg, lines, harmony.DefaultWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	return songio.InsertModulations(lines, modulations), nil
+}
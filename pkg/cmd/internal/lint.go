@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/craiggwilson/songtool/pkg/cmd/internal/config"
+	"github.com/craiggwilson/songtool/pkg/lint"
+)
+
+type LintCmd struct {
+	JSON bool `name:"json" help:"Prints the output as JSON."`
+
+	Paths []string `arg:"" optional:"" type:"existingfile" help:"The paths to the songs; reads from stdin when none are provided."`
+}
+
+func (cmd *LintCmd) Run(cfg *config.Config) error {
+	lintCfg := lint.Config{
+		AllowedSections: cfg.Lint.AllowedSections,
+	}
+
+	diagnostics := []lint.Diagnostic{}
+	if len(cmd.Paths) == 0 {
+		ds, err := cmd.lint(cfg, lintCfg, "<stdin>", os.Stdin)
+		if err != nil {
+			return err
+		}
+		diagnostics = append(diagnostics, ds...)
+	}
+
+	for _, path := range cmd.Paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+
+		ds, err := cmd.lint(cfg, lintCfg, path, f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("linting %q: %w", path, err)
+		}
+		diagnostics = append(diagnostics, ds...)
+	}
+
+	if cmd.JSON {
+		if err := printJSON(diagnostics); err != nil {
+			return err
+		}
+	} else {
+		for _, d := range diagnostics {
+			fmt.Println(d)
+		}
+	}
+
+	if errors := lint.CountErrors(diagnostics); errors > 0 {
+		return fmt.Errorf("found %d errors", errors)
+	}
+
+	return nil
+}
+
+func (cmd *LintCmd) lint(cfg *config.Config, lintCfg lint.Config, path string, r io.Reader) ([]lint.Diagnostic, error) {
+	return lint.Lint(cfg.Theory, path, r, lintCfg)
+}
@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/craiggwilson/songtool/pkg/cmd/internal/config"
+	"github.com/craiggwilson/songtool/pkg/library"
+)
+
+type LsCmd struct {
+	Sort    string `name:"sort" enum:"path,title,key,artist,tempo,chords,difficulty" default:"path" help:"The column to sort by: path, title, key, artist, tempo, chords, or difficulty; defaults to 'path'."`
+	Reverse bool   `name:"reverse" short:"r" help:"Reverses the order of the songs."`
+	NoCache bool   `name:"no-cache" help:"Reads every song rather than using the library cache."`
+	JSON    bool   `name:"json" help:"Prints the output as JSON."`
+
+	Dir string `arg:"" optional:"" type:"existingdir" default:"." help:"The directory to list the songs in, including its subdirectories; defaults to the current directory."`
+}
+
+func (cmd *LsCmd) Run(cfg *config.Config) error {
+	songs, err := scanLibrary(cfg, cmd.Dir, cmd.NoCache)
+	if err != nil {
+		return err
+	}
+
+	sortSongs(songs, cmd.Sort, cmd.Reverse)
+
+	if cmd.JSON {
+		if songs == nil {
+			songs = []library.Song{}
+		}
+		return printJSON(songs)
+	}
+
+	return cmd.print(songs)
+}
+
+func (cmd *LsCmd) print(songs []library.Song) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TITLE\tKEY\tARTIST\tTEMPO\tCHORDS\tPATH")
+	for _, song := range songs {
+		tempo := ""
+		if song.Tempo > 0 {
+			tempo = fmt.Sprint(song.Tempo)
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\n", song.Name(), song.Key, song.Artist, tempo, len(song.Chords), song.Path)
+	}
+
+	return tw.Flush()
+}
+
+// scanLibrary reads the songs under the directory, using the library cache unless noCache is set.
+func scanLibrary(cfg *config.Config, dir string, noCache bool) ([]library.Song, error) {
+	var cache *library.Cache
+	if !noCache {
+		var err error
+		cache, err = library.OpenCache(config.CachePath("library.json"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	songs, err := library.Scan(cfg.Theory, dir, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	return songs, cache.Save()
+}
+
+func sortSongs(songs []library.Song, by string, reverse bool) {
+	less := func(i, j int) bool {
+		switch by {
+		case "title":
+			return strings.ToLower(songs[i].Name()) < strings.ToLower(songs[j].Name())
+		case "key":
+			return songs[i].Key < songs[j].Key
+		case "artist":
+			return strings.ToLower(songs[i].Artist) < strings.ToLower(songs[j].Artist)
+		case "tempo":
+			return songs[i].Tempo < songs[j].Tempo
+		case "chords":
+			return len(songs[i].Chords) < len(songs[j].Chords)
+		case "difficulty":
+			return songs[i].Difficulty < songs[j].Difficulty
+		default:
+			if songs[i].Path != songs[j].Path {
+				return songs[i].Path < songs[j].Path
+			}
+			return songs[i].Index < songs[j].Index
+		}
+	}
+
+	if reverse {
+		sort.SliceStable(songs, func(i, j int) bool { return less(j, i) })
+	} else {
+		sort.SliceStable(songs, less)
+	}
+}
@@ -3,9 +3,11 @@ package internal
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/craiggwilson/songtool/pkg/cmd/internal/config"
 	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
 )
 
 type MetaCmd struct {
@@ -17,17 +19,41 @@ type MetaCmd struct {
 func (cmd *MetaCmd) Run(cfg *config.Config) error {
 	defer cmd.ensurePath().Close()
 
-	song := cmd.openSong(cfg)
+	lines, err := songio.ReadAllLines(cmd.openSong(cfg))
+	if err != nil {
+		return err
+	}
+
+	// Without a song chosen, a songbook is described by its first song.
+	var songs []*songio.Lines
+	allSongs := songio.ReadSongs(songio.FromLines(lines))
+	for song, ok := allSongs.Next(); ok; song, ok = allSongs.Next() {
+		songs = append(songs, song)
+	}
+	if len(songs) > 1 {
+		lines = songs[0].Lines()
+	}
 
-	meta, err := songio.ReadMeta(cfg.Theory, song, true)
+	meta, err := songio.ReadMeta(cfg.Theory, songio.FromLines(lines), true)
 	if err != nil {
 		return err
 	}
 
+	if len(meta.Keys) <= 1 {
+		meta.Modulations, err = detectModulations(cfg, lines, harmony.DefaultWindow)
+		if err != nil {
+			return err
+		}
+	}
+
 	if cmd.JSON {
 		return cmd.printJSON(meta)
 	}
 
+	if len(songs) > 1 {
+		fmt.Printf("Song: 1 of %d\n", len(songs))
+	}
+
 	return cmd.print(cfg, meta)
 }
 
@@ -38,12 +64,68 @@ func (cmd *MetaCmd) print(cfg *config.Config, meta songio.Meta) error {
 		fmt.Println("Title:", "<none>")
 	}
 
+	if len(meta.Artist) > 0 {
+		fmt.Println("Artist:", meta.Artist)
+	}
+
+	if len(meta.Album) > 0 {
+		fmt.Println("Album:", meta.Album)
+	}
+
+	if meta.Year > 0 {
+		fmt.Println("Year:", meta.Year)
+	}
+
 	if meta.Key != nil {
 		fmt.Println("Key:", meta.Key.Name)
 	} else {
 		fmt.Println("Key:", "<none>")
 	}
 
+	if meta.Capo > 0 {
+		fmt.Println("Capo:", meta.Capo)
+	}
+
+	if meta.Tempo > 0 {
+		fmt.Printf("Tempo: %d bpm\n", meta.Tempo)
+	}
+
+	if meta.Time != nil {
+		fmt.Println("Time:", meta.Time)
+	}
+
+	if meta.Duration > 0 {
+		fmt.Println("Duration:", songio.FormatDuration(meta.Duration))
+	}
+
+	if len(meta.Keys) > 1 {
+		fmt.Print("Key Changes: ")
+		for i, region := range meta.Keys[1:] {
+			if i != 0 {
+				fmt.Print(", ")
+			}
+			fmt.Print(region.Key.Name)
+			if region.StartLine > 0 {
+				fmt.Printf(" (line %d)", region.StartLine)
+			}
+		}
+		fmt.Println()
+	}
+
+	if len(meta.Modulations) > 0 {
+		fmt.Print("Inferred Key Changes: ")
+		for i, m := range meta.Modulations {
+			if i != 0 {
+				fmt.Print(", ")
+			}
+			fmt.Print(m.Key.Name)
+			if m.Line > 0 {
+				fmt.Printf(" (line %d)", m.Line)
+			}
+		}
+		fmt.Println()
+	}
+
 	if len(meta.Sections) > 0 {
 		fmt.Print("Sections: ")
 		for i, section := range meta.Sections {
@@ -55,6 +137,17 @@ func (cmd *MetaCmd) print(cfg *config.Config, meta songio.Meta) error {
 		fmt.Println()
 	}
 
+	if !equalStrings(meta.Order, meta.Sections) {
+		fmt.Print("Order: ")
+		for i, section := range meta.Order {
+			if i != 0 {
+				fmt.Print(", ")
+			}
+			fmt.Print(section)
+		}
+		fmt.Println()
+	}
+
 	if len(meta.Chords) > 0 {
 		fmt.Print("Chords: ")
 		for i, chord := range meta.Chords {
@@ -66,6 +159,26 @@ func (cmd *MetaCmd) print(cfg *config.Config, meta songio.Meta) error {
 		fmt.Println()
 	}
 
+	if meta.Difficulty > 0 {
+		fmt.Printf("Difficulty: %d/%d\n", meta.Difficulty, harmony.MaxDifficulty)
+	}
+
+	if len(meta.Tags) > 0 {
+		fmt.Println("Tags:", strings.Join(meta.Tags, ", "))
+	}
+
+	if len(meta.CCLI) > 0 {
+		fmt.Println("CCLI:", meta.CCLI)
+	}
+
+	if len(meta.Copyright) > 0 {
+		fmt.Println("Copyright:", meta.Copyright)
+	}
+
+	for _, comment := range meta.Comments {
+		fmt.Println("Comment:", comment)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/craiggwilson/songtool/pkg/cmd/internal/config"
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
+)
+
+type ReharmCmd struct {
+	songCmd
+
+	Chords  string   `name:"chords" help:"A list of chords to reharmonize instead of a song, such as 'C Am F G'."`
+	Key     string   `name:"key" help:"The key of the chords; will be discovered automatically when not specified."`
+	Rules   []string `name:"rule" enum:"tritone,relative,secondary-dominant,passing-diminished,modal-interchange" help:"The substitution rules to use, in order of preference; defaults to all of them."`
+	Rewrite bool     `name:"rewrite" xor:"output" help:"Prints the song rewritten with the first substitution for each chord instead of listing them."`
+	Style   string   `name:"style" help:"The chord style used to name the chords, such as 'pop', 'jazz', or 'classical'; defaults to the chordStyle in the config."`
+	JSON    bool     `name:"json" xor:"output" help:"Prints the output as JSON."`
+}
+
+func (cmd *ReharmCmd) Run(cfg *config.Config) error {
+	defer cmd.ensurePath().Close()
+
+	var song songio.Reader
+	if len(cmd.Chords) > 0 {
+		song = songio.ReadChordsOverLyrics(cfg.Theory, cfg.Theory, strings.NewReader(cmd.Chords))
+	} else {
+		song = cmd.openSong(cfg)
+	}
+
+	song, err := styleChords(cfg, song, cmd.Style)
+	if err != nil {
+		return err
+	}
+
+	lines, err := songio.ReadAllLines(song)
+	if err != nil {
+		return err
+	}
+
+	start, err := cmd.startKey(cfg, lines)
+	if err != nil {
+		return err
+	}
+
+	normalizer := chord.Normalizer(cfg.Theory)
+	if styled, ok := song.(*songio.ChordNormalizer); ok {
+		normalizer = styled.Normalizer()
+	}
+
+	rules := harmony.Rules
+	if len(cmd.Rules) > 0 {
+		rules = nil
+		for _, name := range cmd.Rules {
+			rule, err := harmony.ParseRule(name)
+			if err != nil {
+				return err
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	substitutions := songio.SuggestSubstitutions(normalizer, lines, start)
+	for i := range substitutions {
+		substitutions[i].Substitutions = filterSubstitutions(substitutions[i].Substitutions, rules)
+	}
+
+	switch {
+	case cmd.Rewrite:
+		rewritten := songio.FromLines(songio.ApplySubstitutions(lines, substitutions, rules))
+		_, err := songio.WriteChordsOverLyrics(cfg.Theory, rewritten, os.Stdout)
+		return err
+	case cmd.JSON:
+		return printJSON(substitutions)
+	default:
+		return cmd.print(substitutions)
+	}
+}
+
+func (cmd *ReharmCmd) startKey(cfg *config.Config, lines []songio.Line) (key.Named, error) {
+	if len(cmd.Key) > 0 {
+		k, err := cfg.Theory.ParseKey(cmd.Key)
+		if err != nil {
+			return key.Named{}, fmt.Errorf("invalid key: %w", err)
+		}
+		return k, nil
+	}
+
+	var chords []chord.Chord
+	for _, line := range lines {
+		switch tl := line.(type) {
+		case *songio.KeyDirectiveLine:
+			return tl.Key, nil
+		case *songio.ChordLine:
+			for _, co := range tl.Chords {
+				chords = append(chords, co.Chord.Chord)
+			}
+		}
+	}
+
+	detected, ok := harmony.DetectKey(chords[:minInt(harmony.DefaultWindow, len(chords))])
+	if !ok {
+		return key.Named{}, fmt.Errorf("could not infer key")
+	}
+
+	return cfg.Theory.ParseKey(cfg.Theory.NameKey(detected))
+}
+
+func (cmd *ReharmCmd) print(substitutions []songio.ChordSubstitutions) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, cs := range substitutions {
+		// Chords given on the command line have no location in a song.
+		location := ""
+		if len(cmd.Chords) == 0 {
+			location = fmt.Sprintf("line %d\t%s\t", cs.Line, cs.Section)
+		}
+
+		if len(cs.Substitutions) == 0 {
+			fmt.Fprintf(tw, "%s%s\n", location, cs.Chord.Name)
+			continue
+		}
+
+		for i, sub := range cs.Substitutions {
+			replacement := sub.Chord.Name
+			if sub.Before {
+				replacement = sub.Chord.Name + " " + cs.Chord.Name
+			}
+
+			if i == 0 {
+				fmt.Fprintf(tw, "%s%s\t%s\t%s\n", location, cs.Chord.Name, sub.Rule, replacement)
+			} else {
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", strings.Repeat("\t", strings.Count(location, "\t")), sub.Rule, replacement)
+			}
+		}
+	}
+
+	return tw.Flush()
+}
+
+func filterSubstitutions(subs []songio.Substitution, rules []harmony.Rule) []songio.Substitution {
+	result := []songio.Substitution{}
+	for _, rule := range rules {
+		for _, sub := range subs {
+			if sub.Rule == rule {
+				result = append(result, sub)
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/craiggwilson/songtool/pkg/cmd/internal/config"
+	"github.com/craiggwilson/songtool/pkg/library"
+)
+
+type SearchCmd struct {
+	Dir     string `name:"dir" short:"d" type:"existingdir" default:"." help:"The directory to search, including its subdirectories; defaults to the current directory."`
+	Limit   int    `name:"limit" short:"n" help:"The most results to print; 0 prints them all."`
+	NoCache bool   `name:"no-cache" help:"Reads every song rather than using the library cache."`
+	JSON    bool   `name:"json" help:"Prints the output as JSON."`
+
+	Query []string `arg:"" optional:"" help:"The query, such as 'key:G chord:Bm7 tempo:>100 grace'; terms are key:, chord:, section:, title:, artist:, album:, tag:, tempo:, year:, capo:, difficulty:, has:, and free text over titles and lyrics. Negated terms, such as '-key:G', follow '--'."`
+}
+
+func (cmd *SearchCmd) Run(cfg *config.Config) error {
+	query, err := library.ParseQuery(cfg.Theory, cfg.Theory, joinQuery(cmd.Query))
+	if err != nil {
+		return err
+	}
+
+	songs, err := scanLibrary(cfg, cmd.Dir, cmd.NoCache)
+	if err != nil {
+		return err
+	}
+	sortSongs(songs, "path", false)
+
+	results := query.Search(songs)
+	if cmd.Limit > 0 && len(results) > cmd.Limit {
+		results = results[:cmd.Limit]
+	}
+
+	if cmd.JSON {
+		if results == nil {
+			results = []library.Result{}
+		}
+		return printJSON(results)
+	}
+
+	return cmd.print(results)
+}
+
+func (cmd *SearchCmd) print(results []library.Result) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SCORE\tTITLE\tKEY\tARTIST\tPATH")
+	for _, result := range results {
+		song := result.Song
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", result.Score, song.Name(), song.Key, song.Artist, song.Path)
+	}
+
+	return tw.Flush()
+}
+
+// joinQuery joins the arguments into the text of a query, quoting the values of the arguments that have spaces, since
+// the shell has already removed their quotes.
+func joinQuery(args []string) string {
+	words := make([]string, len(args))
+	for i, arg := range args {
+		if !strings.ContainsAny(arg, " \t") || strings.ContainsRune(arg, '"') {
+			words[i] = arg
+			continue
+		}
+
+		if idx := strings.IndexRune(arg, ':'); idx > 0 {
+			words[i] = arg[:idx+1] + `"` + arg[idx+1:] + `"`
+		} else {
+			words[i] = `"` + arg + `"`
+		}
+	}
+
+	return strings.Join(words, " ")
+}
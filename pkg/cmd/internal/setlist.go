@@ -0,0 +1,278 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/craiggwilson/songtool/pkg/cmd/internal/app/message"
+	"github.com/craiggwilson/songtool/pkg/cmd/internal/config"
+	"github.com/craiggwilson/songtool/pkg/setlist"
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
+)
+
+type SetlistCmd struct {
+	App  SetlistAppCmd  `cmd:"" help:"Plays the setlist in the songtool interactive TUI."`
+	Cat  SetlistCatCmd  `cmd:"" help:"Prints every song in the setlist, in its key."`
+	Meta SetlistMetaCmd `cmd:"" help:"Summarizes the keys of the songs in the setlist and the changes between them."`
+
+	Transitions SetlistTransitionsCmd `cmd:"" help:"Suggests how to move between the keys of adjacent songs in the setlist."`
+}
+
+type setlistCmd struct {
+	Path string `arg:"" type:"existingfile" help:"The path to the setlist, which may be YAML, TOML, or JSON."`
+}
+
+func (cmd *setlistCmd) open(cfg *config.Config) (*setlist.Setlist, []*setlist.Song, error) {
+	s, err := setlist.Load(cmd.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	songs := make([]*setlist.Song, 0, len(s.Songs))
+	for i := range s.Songs {
+		song, err := s.Open(cfg.Theory, i)
+		if err != nil {
+			return nil, nil, err
+		}
+		songs = append(songs, song)
+	}
+
+	return s, songs, nil
+}
+
+type SetlistAppCmd struct {
+	setlistCmd
+}
+
+func (cmd *SetlistAppCmd) Run(cfg *config.Config) error {
+	if _, err := setlist.Load(cmd.Path); err != nil {
+		return err
+	}
+
+	return runApp(cfg, message.LoadSetlist(cmd.Path), message.EnterSongMode())
+}
+
+type SetlistCatCmd struct {
+	setlistCmd
+
+	Style string `name:"style" help:"The chord style used to name the chords, such as 'pop', 'jazz', or 'classical'; defaults to the chordStyle in the config."`
+}
+
+func (cmd *SetlistCatCmd) Run(cfg *config.Config) error {
+	_, songs, err := cmd.open(cfg)
+	if err != nil {
+		return err
+	}
+
+	// The setlist is printed as a songbook.
+	var lines []songio.Line
+	for i, song := range songs {
+		if i > 0 {
+			if _, ok := lines[len(lines)-1].(*songio.SectionEndDirectiveLine); !ok {
+				lines = append(lines, songio.EmptyLine{})
+			}
+			lines = append(lines, &songio.SongBreakLine{})
+		}
+		lines = append(lines, song.Lines...)
+	}
+
+	styled, err := styleChords(cfg, songio.FromLines(lines), cmd.Style)
+	if err != nil {
+		return err
+	}
+
+	if _, err := songio.WriteChordsOverLyrics(cfg.Theory, styled, os.Stdout); err != nil {
+		return err
+	}
+
+	return styled.Err()
+}
+
+type SetlistMetaCmd struct {
+	setlistCmd
+
+	JSON bool `name:"json" help:"Prints the output as JSON."`
+}
+
+type setlistSummary struct {
+	Title       string              `json:"title,omitempty"`
+	Songs       []setlistSongMeta   `json:"songs"`
+	Transitions []setlistTransition `json:"transitions,omitempty"`
+}
+
+type setlistSongMeta struct {
+	Title   string `json:"title"`
+	Path    string `json:"path"`
+	Written string `json:"written,omitempty"`
+	Key     string `json:"key,omitempty"`
+	Capo    int    `json:"capo,omitempty"`
+	Shape   string `json:"shape,omitempty"`
+	Notes   string `json:"notes,omitempty"`
+}
+
+type setlistTransition struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Steps is the number of half steps from one key to the other, from -5 to 6.
+	Steps int `json:"steps"`
+}
+
+func (cmd *SetlistMetaCmd) Run(cfg *config.Config) error {
+	s, songs, err := cmd.open(cfg)
+	if err != nil {
+		return err
+	}
+
+	summary := setlistSummary{Title: s.Title, Songs: []setlistSongMeta{}}
+	for i, song := range songs {
+		sm := setlistSongMeta{
+			Title: song.Title,
+			Path:  song.Path,
+			Capo:  song.Capo,
+			Notes: song.Notes,
+		}
+		if song.Key != nil {
+			sm.Written = song.Written.Name
+			sm.Key = song.Key.Name
+			sm.Shape = song.Shape.Name
+		}
+		summary.Songs = append(summary.Songs, sm)
+
+		if i > 0 && song.Key != nil && songs[i-1].Key != nil {
+			from, to := songs[i-1].Key, song.Key
+			summary.Transitions = append(summary.Transitions, setlistTransition{
+				From:  from.Name,
+				To:    to.Name,
+				Steps: harmony.PlanTransition(from.Key, to.Key).Steps,
+			})
+		}
+	}
+
+	if cmd.JSON {
+		return printJSON(summary)
+	}
+
+	return cmd.print(summary)
+}
+
+func (cmd *SetlistMetaCmd) print(summary setlistSummary) error {
+	if len(summary.Title) > 0 {
+		fmt.Println("Setlist:", summary.Title)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tTITLE\tKEY\tWRITTEN\tCAPO\tSHAPES\tNOTES")
+	for i, song := range summary.Songs {
+		capo := ""
+		if song.Capo > 0 {
+			capo = fmt.Sprint(song.Capo)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, song.Title, song.Key, song.Written, capo, song.Shape, song.Notes)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(summary.Transitions) > 0 {
+		fmt.Println()
+		fmt.Println("Transitions:")
+		for _, t := range summary.Transitions {
+			fmt.Printf("  %s -> %s: %s\n", t.From, t.To, describeSteps(t.Steps))
+		}
+	}
+
+	return nil
+}
+
+func describeSteps(steps int) string {
+	switch {
+	case steps == 0:
+		return "same key"
+	case steps == 1:
+		return "up a half step"
+	case steps == -1:
+		return "down a half step"
+	case steps > 0:
+		return fmt.Sprintf("up %d half steps", steps)
+	default:
+		return fmt.Sprintf("down %d half steps", -steps)
+	}
+}
+
+type SetlistTransitionsCmd struct {
+	setlistCmd
+
+	JSON bool `name:"json" help:"Prints the output as JSON."`
+}
+
+type setlistTransitionPlan struct {
+	From         string   `json:"from"`
+	FromKey      string   `json:"fromKey"`
+	To           string   `json:"to"`
+	ToKey        string   `json:"toKey"`
+	Interval     string   `json:"interval"`
+	Steps        int      `json:"steps"`
+	Relationship string   `json:"relationship"`
+	Pivots       []string `json:"pivots,omitempty"`
+	Dominant     string   `json:"dominant"`
+	Alternatives []string `json:"alternatives,omitempty"`
+}
+
+func (cmd *SetlistTransitionsCmd) Run(cfg *config.Config) error {
+	_, songs, err := cmd.open(cfg)
+	if err != nil {
+		return err
+	}
+
+	plans := []setlistTransitionPlan{}
+	for i := 1; i < len(songs); i++ {
+		from, to := songs[i-1], songs[i]
+		if from.Key == nil || to.Key == nil {
+			continue
+		}
+
+		t := harmony.PlanTransition(from.Key.Key, to.Key.Key)
+		plan := setlistTransitionPlan{
+			From:         from.Title,
+			FromKey:      from.Key.Name,
+			To:           to.Title,
+			ToKey:        to.Key.Name,
+			Interval:     t.Interval.String(),
+			Steps:        t.Steps,
+			Relationship: string(t.Relationship),
+			Dominant:     cfg.Theory.NameChord(t.Dominant),
+		}
+		for _, c := range t.Pivots {
+			plan.Pivots = append(plan.Pivots, cfg.Theory.NameChord(c))
+		}
+		for _, k := range t.Alternatives {
+			plan.Alternatives = append(plan.Alternatives, cfg.Theory.NameKey(k))
+		}
+		plans = append(plans, plan)
+	}
+
+	if cmd.JSON {
+		return printJSON(plans)
+	}
+
+	for i, plan := range plans {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s (%s) -> %s (%s)\n", plan.From, plan.FromKey, plan.To, plan.ToKey)
+		fmt.Printf("  Interval:     %s, %s\n", plan.Interval, describeSteps(plan.Steps))
+		fmt.Printf("  Relationship: %s\n", plan.Relationship)
+		if len(plan.Pivots) > 0 {
+			fmt.Printf("  Pivots:       %s\n", strings.Join(plan.Pivots, " "))
+		}
+		fmt.Printf("  Dominant:     %s\n", plan.Dominant)
+		if len(plan.Alternatives) > 0 {
+			fmt.Printf("  Closer keys:  %s\n", strings.Join(plan.Alternatives, " "))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/alecthomas/kong"
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+)
+
+// simplifyLevel is a flag that may be given without a value, meaning the default level, or with a level such as
+// --simplify=3.
+type simplifyLevel int
+
+func (l *simplifyLevel) Decode(ctx *kong.DecodeContext) error {
+	if ctx.Scan.Peek().Type != kong.FlagValueToken {
+		*l = chord.SimplifyTriads
+		return nil
+	}
+
+	text := ctx.Scan.Pop().String()
+	level, err := strconv.Atoi(text)
+	if err != nil || level < 0 || level > chord.SimplifyMajorMinor {
+		return fmt.Errorf("expected a level from 0 to %d but got %q", chord.SimplifyMajorMinor, text)
+	}
+
+	*l = simplifyLevel(level)
+	return nil
+}
+
+func (l *simplifyLevel) IsBool() bool {
+	return true
+}
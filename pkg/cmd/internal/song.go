@@ -1,14 +1,18 @@
 package internal
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/craiggwilson/songtool/pkg/cmd/internal/config"
 	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
 )
 
 type songCmd struct {
 	Format string   `name:"format" enum:"auto,chordsOverLyrics" default:"auto" help:"The format of the song; defaults to 'auto'."`
+	Strict bool     `name:"strict" help:"Fails when a line that is mostly chords has words that are not chords, rather than reading it as lyrics."`
+	Song   string   `name:"song" help:"The song to use from a file with more than one song, by its number, starting at 1, or its title."`
 	Path   *os.File `arg:"" optional:"" help:"The path to the song; '-' can be used for stdin."`
 }
 
@@ -20,5 +24,42 @@ func (cmd *songCmd) ensurePath() *os.File {
 }
 
 func (cmd *songCmd) openSong(cfg *config.Config) songio.Reader {
-	return songio.ReadChordsOverLyrics(cfg.Theory, cfg.Theory, cmd.Path)
+	song := songio.ReadChordsOverLyrics(cfg.Theory, cfg.Theory, cmd.Path)
+	song.Strict = cmd.Strict
+	if len(cmd.Song) > 0 {
+		return songio.SelectSong(song, cmd.Song)
+	}
+	return song
+}
+
+// styleChords renames the chords in the song using the chord style. When the style is empty, the chord style from the
+// config is used, and when neither is set the chords are left as written.
+func styleChords(cfg *config.Config, song songio.Reader, style string) (songio.Reader, error) {
+	if len(style) == 0 {
+		style = cfg.File.Theory.ChordStyle
+	}
+
+	if len(style) == 0 {
+		return song, nil
+	}
+
+	styled, err := cfg.Theory.WithChordStyle(style)
+	if err != nil {
+		return nil, fmt.Errorf("invalid style: %w", err)
+	}
+
+	return songio.NormalizeChords(styled, song), nil
+}
+
+// detectModulations infers the key changes in the song, starting from the song's key directive when it has one.
+func detectModulations(cfg *config.Config, lines []songio.Line, window int) ([]songio.Modulation, error) {
+	var start *key.Key
+	for _, line := range lines {
+		if kdl, ok := line.(*songio.KeyDirectiveLine); ok {
+			start = &kdl.Key.Key
+			break
+		}
+	}
+
+	return songio.DetectModulations(cfg.Theory, cfg.Theory, lines, start, window)
 }
@@ -17,8 +17,9 @@ type TransposeCmd struct {
 	Interval int    `name:"interval" short:"i" xor:"keyinterval" required:"" help:"The number of steps to transpose the song; can be negative. Cannot be used to 'to-key'."`
 	ToKey    string `name:"to-key" xor:"keyinterval" required:"" help:"The desired key of the song. Cannot be used with 'interval'."`
 
-	JSON  bool `name:"json" xor:"json" help:"Prints the output as JSON."`
-	Color bool `name:"color" xor:"json" negatable:"" help:"Indicates whether to use color"`
+	Style string `name:"style" help:"The chord style used to name the chords, such as 'pop', 'jazz', or 'classical'; defaults to the chordStyle in the config."`
+	JSON  bool   `name:"json" xor:"json" help:"Prints the output as JSON."`
+	Color bool   `name:"color" xor:"json" negatable:"" help:"Indicates whether to use color"`
 }
 
 func (cmd *TransposeCmd) Run(cfg *config.Config) error {
@@ -62,8 +63,14 @@ func (cmd *TransposeCmd) Run(cfg *config.Config) error {
 		intval = fromKey.Note().Step(cmd.Interval)
 	}
 
-	transposer := songio.Transpose(cfg.Theory, song, intval)
+	transposed, err := styleChords(cfg, songio.Transpose(cfg.Theory, song, intval), cmd.Style)
+	if err != nil {
+		return err
+	}
+
+	if _, err = songio.WriteChordsOverLyrics(cfg.Theory, transposed, os.Stdout); err != nil {
+		return err
+	}
 
-	_, err := songio.WriteChordsOverLyrics(cfg.Theory, transposer, os.Stdout)
-	return err
+	return transposed.Err()
 }
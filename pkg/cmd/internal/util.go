@@ -3,10 +3,38 @@ package internal
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/interval"
 	"github.com/craiggwilson/songtool/pkg/theory/key"
+	"github.com/craiggwilson/songtool/pkg/theory/note"
 )
 
+func describeInversion(inversion chord.Inversion) string {
+	switch inversion {
+	case chord.InversionSlash:
+		return "slash"
+	case chord.InversionRoot:
+		return "root position"
+	case chord.InversionFirst:
+		return "1st inversion"
+	case chord.InversionSecond:
+		return "2nd inversion"
+	case chord.InversionThird:
+		return "3rd inversion"
+	default:
+		return strconv.Itoa(int(inversion)) + "th inversion"
+	}
+}
+
+func minInt(a, b int) int {
+	if a <= b {
+		return a
+	}
+	return b
+}
+
 func marshalJSON(v interface{}) ([]byte, error) {
 	return json.MarshalIndent(v, "", " ")
 }
@@ -39,3 +67,35 @@ type scaleSurrogate struct {
 	Name  string          `json:"name"`
 	Notes []noteSurrogate `json:"notes"`
 }
+
+// chordSurrogate is a parsed chord along with its inversion and alternative names.
+type chordSurrogate struct {
+	Name              string              `json:"name"`
+	Root              note.Note           `json:"root"`
+	Suffix            string              `json:"suffix"`
+	BaseNoteDelimiter string              `json:"baseNoteDelimiter,omitempty"`
+	Base              *note.Note          `json:"base"`
+	Intervals         []interval.Interval `json:"intervals"`
+	Inversion         chord.Inversion     `json:"inversion"`
+	AlternativeNames  []string            `json:"alternativeNames"`
+}
+
+type voicingSurrogate struct {
+	Name      string   `json:"name"`
+	Voices    []string `json:"voices"`
+	Inversion int      `json:"inversion"`
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
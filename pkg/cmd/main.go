@@ -16,13 +16,22 @@ import (
 )
 
 var mainCmd struct {
+	Analyze   internal.AnalyzeCmd   `cmd:"" help:"Analyzes the harmony of a song."`
 	App       internal.AppCmd       `cmd:"" help:"Loads the songtool interactive TUI." default:"withargs"`
+	Book      internal.BookCmd      `cmd:"" help:"Compiles songs into a printable songbook."`
 	Cat       internal.CatCmd       `cmd:"" help:"Displays a song."`
 	Chords    internal.ChordsCmd    `cmd:"" help:"Tools for working with chords."`
 	Config    internal.ConfigCmd    `cmd:"" help:"Tools for managin the config."`
+	Export    internal.ExportCmd    `cmd:"" help:"Exports songs to other formats."`
+	Fmt       internal.FmtCmd       `cmd:"" help:"Formats songs canonically."`
 	Keys      internal.KeysCmd      `cmd:"" help:"Tools for working with keys."`
-	Meta      internal.MetaCmd      `cmd:"" help:"Displays the meta information about a song."`
+	Lint      internal.LintCmd      `cmd:"" help:"Checks songs for problems."`
+	Ls        internal.LsCmd        `cmd:"" help:"Lists the songs in a directory and its subdirectories."`
+	Meta      internal.MetaCmd      `cmd:"" help:"Displays the meta information about a song; only the first song of a songbook is described unless --song chooses another."`
+	Reharm    internal.ReharmCmd    `cmd:"" help:"Suggests chord substitutions for a song."`
 	Scales    internal.ScalesCmd    `cmd:"" help:"Tools for working with scales."`
+	Search    internal.SearchCmd    `cmd:"" help:"Searches the songs in a directory and its subdirectories."`
+	Setlist   internal.SetlistCmd   `cmd:"" help:"Tools for working with setlists."`
 	Transpose internal.TransposeCmd `cmd:"" help:"Transposes a song."`
 
 	LogFile string `name:"logfile"`
@@ -0,0 +1,130 @@
+package library
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cacheVersion is changed whenever the songs are described differently, so that older caches are not used.
+const cacheVersion = 2
+
+// Cache holds the songs read from each file, keyed by the file's path and modification time, so that a library can be
+// scanned without reading the files that have not changed.
+type Cache struct {
+	path  string
+	file  cacheFile
+	dirty bool
+}
+
+type cacheFile struct {
+	Version int                    `json:"version"`
+	Files   map[string]cachedSongs `json:"files"`
+}
+
+type cachedSongs struct {
+	ModTime time.Time `json:"modTime"`
+	Size    int64     `json:"size"`
+	Songs   []Song    `json:"songs"`
+}
+
+// OpenCache reads the cache at the path. A cache that does not exist yet, or that was written by a different version,
+// is empty.
+func OpenCache(path string) (*Cache, error) {
+	c := &Cache{
+		path: path,
+		file: cacheFile{
+			Version: cacheVersion,
+			Files:   make(map[string]cachedSongs),
+		},
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading library cache: %w", err)
+	}
+
+	var file cacheFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Version != cacheVersion || file.Files == nil {
+		// A cache that can't be used is rebuilt.
+		c.dirty = true
+		return c, nil
+	}
+
+	c.file = file
+	return c, nil
+}
+
+// Save writes the cache when it has changed.
+func (c *Cache) Save() error {
+	if c == nil || !c.dirty {
+		return nil
+	}
+
+	data, err := json.Marshal(c.file)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("writing library cache: %w", err)
+	}
+
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("writing library cache: %w", err)
+	}
+
+	c.dirty = false
+	return nil
+}
+
+func (c *Cache) lookup(path string, info os.FileInfo) ([]Song, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	cached, ok := c.file.Files[path]
+	if !ok || !cached.ModTime.Equal(info.ModTime()) || cached.Size != info.Size() {
+		return nil, false
+	}
+
+	return cached.Songs, true
+}
+
+func (c *Cache) store(path string, info os.FileInfo, songs []Song) {
+	if c == nil {
+		return
+	}
+
+	c.file.Files[path] = cachedSongs{
+		ModTime: info.ModTime(),
+		Size:    info.Size(),
+		Songs:   songs,
+	}
+	c.dirty = true
+}
+
+// prune removes the files under the root that were not seen, since they have been deleted.
+func (c *Cache) prune(root string, seen map[string]struct{}) {
+	if c == nil {
+		return
+	}
+
+	prefix := root + string(filepath.Separator)
+	for path := range c.file.Files {
+		if path != root && !strings.HasPrefix(path, prefix) {
+			continue
+		}
+
+		if _, ok := seen[path]; !ok {
+			delete(c.file.Files, path)
+			c.dirty = true
+		}
+	}
+}
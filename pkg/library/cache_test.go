@@ -0,0 +1,93 @@
+package library_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/craiggwilson/songtool/pkg/library"
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan_Cache(t *testing.T) {
+	root := t.TempDir()
+	cachePath := filepath.Join(t.TempDir(), "cache", "library.json")
+	songPath := filepath.Join(root, "song.txt")
+	modTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	writeSong := func(text string, modTime time.Time) {
+		require.Nil(t, os.WriteFile(songPath, []byte(text), 0644))
+		require.Nil(t, os.Chtimes(songPath, modTime, modTime))
+	}
+
+	scan := func() []string {
+		cache, err := library.OpenCache(cachePath)
+		require.Nil(t, err)
+
+		songs, err := library.Scan(theory.Default(), root, cache)
+		require.Nil(t, err)
+		require.Nil(t, cache.Save())
+
+		var titles []string
+		for _, song := range songs {
+			titles = append(titles, song.Title)
+		}
+		return titles
+	}
+
+	writeSong("#title=Abc\n[Verse]\nla\n", modTime)
+	require.Equal(t, []string{"Abc"}, scan())
+
+	// A file with the same modification time and size is not read again.
+	writeSong("#title=Xyz\n[Verse]\nla\n", modTime)
+	require.Equal(t, []string{"Abc"}, scan())
+
+	t.Run("modification time", func(t *testing.T) {
+		writeSong("#title=Xyz\n[Verse]\nla\n", modTime.Add(time.Minute))
+		require.Equal(t, []string{"Xyz"}, scan())
+	})
+
+	t.Run("size", func(t *testing.T) {
+		writeSong("#title=Longer\n[Verse]\nla\n", modTime.Add(time.Minute))
+		require.Equal(t, []string{"Longer"}, scan())
+	})
+
+	t.Run("deleted files", func(t *testing.T) {
+		otherPath := filepath.Join(root, "other.txt")
+		require.Nil(t, os.WriteFile(otherPath, []byte("#title=Other\n"), 0644))
+		require.Equal(t, []string{"Other", "Longer"}, scan())
+
+		data, err := os.ReadFile(cachePath)
+		require.Nil(t, err)
+		require.True(t, strings.Contains(string(data), "other.txt"))
+
+		require.Nil(t, os.Remove(otherPath))
+		require.Equal(t, []string{"Longer"}, scan())
+
+		data, err = os.ReadFile(cachePath)
+		require.Nil(t, err)
+		require.False(t, strings.Contains(string(data), "other.txt"))
+	})
+}
+
+func TestOpenCache_Unusable(t *testing.T) {
+	root := t.TempDir()
+	cachePath := filepath.Join(t.TempDir(), "library.json")
+	require.Nil(t, os.WriteFile(filepath.Join(root, "song.txt"), []byte("#title=Abc\n"), 0644))
+	require.Nil(t, os.WriteFile(cachePath, []byte(`{"version":1,"files":{}}`), 0644))
+
+	cache, err := library.OpenCache(cachePath)
+	require.Nil(t, err)
+
+	songs, err := library.Scan(theory.Default(), root, cache)
+	require.Nil(t, err)
+	require.Len(t, songs, 1)
+	require.Nil(t, cache.Save())
+
+	data, err := os.ReadFile(cachePath)
+	require.Nil(t, err)
+	require.True(t, strings.Contains(string(data), "song.txt"))
+}
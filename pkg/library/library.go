@@ -0,0 +1,189 @@
+package library
+
+import (
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory"
+)
+
+// Song is the description of a song in the library. A file with more than one song has an entry for each of them.
+type Song struct {
+	Path string `json:"path"`
+	// Index is the position of the song in its file, starting at 0, and Count is the number of songs in the file.
+	Index int `json:"index,omitempty"`
+	Count int `json:"count,omitempty"`
+
+	Title      string   `json:"title"`
+	Artist     string   `json:"artist,omitempty"`
+	Album      string   `json:"album,omitempty"`
+	Year       int      `json:"year,omitempty"`
+	Key        string   `json:"key,omitempty"`
+	Tempo      int      `json:"tempo,omitempty"`
+	Time       string   `json:"time,omitempty"`
+	Capo       int      `json:"capo,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	Sections   []string `json:"sections,omitempty"`
+	Chords     []string `json:"chords,omitempty"`
+	Difficulty int      `json:"difficulty,omitempty"`
+	// Lyrics are the song's lines of text.
+	Lyrics []string `json:"lyrics,omitempty"`
+}
+
+// NewSong describes the song at the index in the file from its meta information.
+func NewSong(path string, index int, meta songio.Meta) Song {
+	song := Song{
+		Path:       path,
+		Index:      index,
+		Title:      meta.Title,
+		Artist:     meta.Artist,
+		Album:      meta.Album,
+		Year:       meta.Year,
+		Tempo:      meta.Tempo,
+		Capo:       meta.Capo,
+		Tags:       meta.Tags,
+		Sections:   meta.Sections,
+		Difficulty: meta.Difficulty,
+	}
+
+	if meta.Key != nil {
+		song.Key = meta.Key.Name
+	}
+
+	if meta.Time != nil {
+		song.Time = meta.Time.String()
+	}
+
+	for _, c := range meta.Chords {
+		song.Chords = append(song.Chords, c.Name)
+	}
+
+	return song
+}
+
+// Name is the song's title, or the name of its file when it has no title.
+func (s *Song) Name() string {
+	if len(s.Title) > 0 {
+		return s.Title
+	}
+
+	name := strings.TrimSuffix(filepath.Base(s.Path), filepath.Ext(s.Path))
+	if s.Count > 1 {
+		name = fmt.Sprintf("%s #%d", name, s.Index+1)
+	}
+
+	return name
+}
+
+// Scan reads the songs in every file under the root, including its subdirectories. Hidden files and directories are
+// skipped. Files that have not changed since they were cached are not read again, and the cache is updated with the
+// files that were. The cache may be nil.
+func Scan(th *theory.Theory, root string, cache *Cache) ([]Song, error) {
+	var songs []Song
+	seen := make(map[string]struct{})
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		seen[abs] = struct{}{}
+
+		if cached, ok := cache.lookup(abs, info); ok {
+			songs = append(songs, withPath(cached, path)...)
+			return nil
+		}
+
+		fileSongs, err := ReadFile(th, path)
+		if err != nil {
+			log.Printf("failed reading %q: %v\n", path, err)
+			return nil
+		}
+
+		cache.store(abs, info, fileSongs)
+		songs = append(songs, fileSongs...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning %q: %w", root, err)
+	}
+
+	if abs, err := filepath.Abs(root); err == nil {
+		cache.prune(abs, seen)
+	}
+
+	return songs, nil
+}
+
+// ReadFile reads the songs in the file.
+func ReadFile(th *theory.Theory, path string) ([]Song, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var songs []Song
+	rdr := songio.ReadSongs(songio.ReadChordsOverLyrics(th, th, f))
+	for song, ok := rdr.Next(); ok; song, ok = rdr.Next() {
+		meta, err := songio.ReadMeta(th, song, true)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := NewSong(path, len(songs), meta)
+		song.Rewind()
+		for line, ok := song.Next(); ok; line, ok = song.Next() {
+			if tl, ok := line.(*songio.TextLine); ok {
+				entry.Lyrics = append(entry.Lyrics, strings.TrimSpace(tl.Text))
+			}
+		}
+
+		songs = append(songs, entry)
+	}
+
+	if err := rdr.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range songs {
+		songs[i].Count = len(songs)
+	}
+
+	return songs, nil
+}
+
+// withPath copies the songs with their path as it was given to Scan, since the cache holds absolute paths.
+func withPath(songs []Song, path string) []Song {
+	result := make([]Song, len(songs))
+	copy(result, songs)
+	for i := range result {
+		result[i].Path = path
+	}
+
+	return result
+}
@@ -0,0 +1,372 @@
+package library
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
+)
+
+// Query selects and ranks songs. It is written as terms separated by spaces, all of which must match:
+//
+//	key:G           the song is in the key, including enharmonic spellings
+//	chord:Bm7       the song has the chord
+//	section:bridge  the song has a section whose name contains the text
+//	artist:text     the artist contains the text, as do title, album, and tag
+//	tempo:>100      the number matches; tempo, year, capo, and difficulty take =, <, <=, >, >=, or a range like 90..120,
+//	                and never match songs without the field
+//	has:capo        the song has the field, one of album, artist, capo, chords, key, lyrics, tags, tempo, time, or year
+//	text            the title, artist, or lyrics contain the text
+//
+// Values with spaces are quoted, as in section:"pre-chorus 2", and a term starting with '-' must not match.
+type Query struct {
+	terms []term
+
+	keyParser   key.Parser
+	chordParser chord.Parser
+}
+
+type term struct {
+	field  string
+	value  string
+	negate bool
+
+	numbers numberRange
+	key     *key.Named
+	chord   *chord.Named
+}
+
+type numberRange struct {
+	min, max int
+}
+
+// Result is a song that matches a query, along with its score. Higher scores match better.
+type Result struct {
+	Song  Song `json:"song"`
+	Score int  `json:"score"`
+}
+
+var numberFields = map[string]func(*Song) int{
+	"capo":       func(s *Song) int { return s.Capo },
+	"difficulty": func(s *Song) int { return s.Difficulty },
+	"tempo":      func(s *Song) int { return s.Tempo },
+	"year":       func(s *Song) int { return s.Year },
+}
+
+var hasFields = map[string]func(*Song) bool{
+	"album":  func(s *Song) bool { return len(s.Album) > 0 },
+	"artist": func(s *Song) bool { return len(s.Artist) > 0 },
+	"capo":   func(s *Song) bool { return s.Capo > 0 },
+	"chords": func(s *Song) bool { return len(s.Chords) > 0 },
+	"key":    func(s *Song) bool { return len(s.Key) > 0 },
+	"lyrics": func(s *Song) bool { return len(s.Lyrics) > 0 },
+	"tags":   func(s *Song) bool { return len(s.Tags) > 0 },
+	"tempo":  func(s *Song) bool { return s.Tempo > 0 },
+	"time":   func(s *Song) bool { return len(s.Time) > 0 },
+	"year":   func(s *Song) bool { return s.Year > 0 },
+}
+
+// ParseQuery parses the text of a query. The parsers are used to compare keys and chords by their notes rather than
+// their names.
+func ParseQuery(keyParser key.Parser, chordParser chord.Parser, text string) (*Query, error) {
+	q := &Query{
+		keyParser:   keyParser,
+		chordParser: chordParser,
+	}
+
+	words, err := splitQuery(text)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, word := range words {
+		var t term
+		if len(word) > 1 && word[0] == '-' {
+			t.negate = true
+			word = word[1:]
+		}
+
+		if idx := strings.IndexRune(word, ':'); idx > 0 {
+			t.field = strings.ToLower(word[:idx])
+			t.value = word[idx+1:]
+		} else {
+			t.value = word
+		}
+
+		if err := q.prepare(&t); err != nil {
+			return nil, err
+		}
+
+		q.terms = append(q.terms, t)
+	}
+
+	return q, nil
+}
+
+func (q *Query) prepare(t *term) error {
+	if len(t.value) == 0 {
+		return fmt.Errorf("query term %q has no value", t.field+":")
+	}
+
+	switch t.field {
+	case "", "title", "artist", "album", "section", "tag":
+	case "key":
+		if k, err := q.keyParser.ParseKey(t.value); err == nil {
+			t.key = &k
+		}
+	case "chord":
+		if c, err := q.chordParser.ParseChord(t.value); err == nil {
+			t.chord = &c
+		}
+	case "has":
+		if _, ok := hasFields[strings.ToLower(t.value)]; !ok {
+			return fmt.Errorf("has:%s is not a field songs may have", t.value)
+		}
+	default:
+		if _, ok := numberFields[t.field]; !ok {
+			return fmt.Errorf("unknown query field %q", t.field)
+		}
+
+		numbers, err := parseNumberRange(t.value)
+		if err != nil {
+			return fmt.Errorf("%s:%s: %w", t.field, t.value, err)
+		}
+		t.numbers = numbers
+	}
+
+	return nil
+}
+
+// HasFields indicates whether the query has any terms other than free text.
+func (q *Query) HasFields() bool {
+	for _, t := range q.terms {
+		if len(t.field) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsEmpty indicates whether the query has no terms, so that it matches every song.
+func (q *Query) IsEmpty() bool {
+	return len(q.terms) == 0
+}
+
+// Match scores the song against the query. It returns false when the song doesn't match.
+func (q *Query) Match(song *Song) (int, bool) {
+	score := 0
+	for i := range q.terms {
+		t := &q.terms[i]
+		s := q.matchTerm(t, song)
+		if (s > 0) == t.negate {
+			return 0, false
+		}
+		score += s
+	}
+
+	return score, true
+}
+
+// Search returns the songs that match the query, with the best matches first.
+func (q *Query) Search(songs []Song) []Result {
+	var results []Result
+	for i := range songs {
+		if score, ok := q.Match(&songs[i]); ok {
+			results = append(results, Result{Song: songs[i], Score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	return results
+}
+
+func (q *Query) matchTerm(t *term, song *Song) int {
+	switch t.field {
+	case "":
+		score := 0
+		if containsFold(song.Title, t.value) {
+			score += 3
+			if strings.EqualFold(song.Title, t.value) {
+				score += 2
+			}
+		}
+		if containsFold(song.Artist, t.value) {
+			score += 2
+		}
+		for _, line := range song.Lyrics {
+			if containsFold(line, t.value) {
+				score++
+			}
+		}
+		return score
+	case "title":
+		return boolScore(containsFold(song.Title, t.value))
+	case "artist":
+		return boolScore(containsFold(song.Artist, t.value))
+	case "album":
+		return boolScore(containsFold(song.Album, t.value))
+	case "tag":
+		for _, tag := range song.Tags {
+			if containsFold(tag, t.value) {
+				return 1
+			}
+		}
+	case "section":
+		for _, section := range song.Sections {
+			if containsFold(section, t.value) {
+				return 1
+			}
+		}
+	case "key":
+		return boolScore(q.matchKey(t, song.Key))
+	case "chord":
+		for _, name := range song.Chords {
+			if q.matchChord(t, name) {
+				return 1
+			}
+		}
+	case "has":
+		return boolScore(hasFields[strings.ToLower(t.value)](song))
+	default:
+		// Zero means the song doesn't have the field.
+		n := numberFields[t.field](song)
+		return boolScore(n != 0 && n >= t.numbers.min && n <= t.numbers.max)
+	}
+
+	return 0
+}
+
+func (q *Query) matchKey(t *term, name string) bool {
+	if len(name) == 0 {
+		return false
+	}
+
+	if t.key != nil {
+		if k, err := q.keyParser.ParseKey(name); err == nil {
+			return k.Kind() == t.key.Kind() && k.Note().PitchClass() == t.key.Note().PitchClass()
+		}
+	}
+
+	return strings.EqualFold(name, t.value)
+}
+
+func (q *Query) matchChord(t *term, name string) bool {
+	if t.chord != nil {
+		if c, err := q.chordParser.ParseChord(name); err == nil {
+			return sameChord(c.Chord, t.chord.Chord)
+		}
+	}
+
+	return strings.EqualFold(name, t.value)
+}
+
+func sameChord(a, b chord.Chord) bool {
+	if a.Root().PitchClass() != b.Root().PitchClass() {
+		return false
+	}
+
+	aBase, bBase := a.Base(), b.Base()
+	if (aBase == nil) != (bBase == nil) || (aBase != nil && aBase.PitchClass() != bBase.PitchClass()) {
+		return false
+	}
+
+	aIntervals, bIntervals := a.Intervals(), b.Intervals()
+	if len(aIntervals) != len(bIntervals) {
+		return false
+	}
+
+	for i := range aIntervals {
+		if aIntervals[i].Chromatic() != bIntervals[i].Chromatic() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseNumberRange(text string) (numberRange, error) {
+	const unbounded = int(^uint(0) >> 1)
+
+	if idx := strings.Index(text, ".."); idx >= 0 {
+		min, err := strconv.Atoi(text[:idx])
+		if err != nil {
+			return numberRange{}, fmt.Errorf("invalid range")
+		}
+		max, err := strconv.Atoi(text[idx+2:])
+		if err != nil {
+			return numberRange{}, fmt.Errorf("invalid range")
+		}
+		return numberRange{min: min, max: max}, nil
+	}
+
+	op := strings.TrimRightFunc(text, func(r rune) bool { return unicode.IsDigit(r) })
+	n, err := strconv.Atoi(text[len(op):])
+	if err != nil {
+		return numberRange{}, fmt.Errorf("invalid number")
+	}
+
+	switch op {
+	case "", "=":
+		return numberRange{min: n, max: n}, nil
+	case ">":
+		return numberRange{min: n + 1, max: unbounded}, nil
+	case ">=":
+		return numberRange{min: n, max: unbounded}, nil
+	case "<":
+		return numberRange{min: -unbounded, max: n - 1}, nil
+	case "<=":
+		return numberRange{min: -unbounded, max: n}, nil
+	default:
+		return numberRange{}, fmt.Errorf("invalid comparison %q", op)
+	}
+}
+
+// splitQuery splits the text on spaces, except within double quotes, which are removed.
+func splitQuery(text string) ([]string, error) {
+	var words []string
+	var sb strings.Builder
+	inQuotes := false
+	for _, r := range text {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case unicode.IsSpace(r) && !inQuotes:
+			if sb.Len() > 0 {
+				words = append(words, sb.String())
+				sb.Reset()
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("query has an unclosed quote")
+	}
+
+	if sb.Len() > 0 {
+		words = append(words, sb.String())
+	}
+
+	return words, nil
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func boolScore(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,130 @@
+package library_test
+
+import (
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/library"
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/stretchr/testify/require"
+)
+
+var testSongs = []library.Song{
+	{
+		Path:     "amazing.txt",
+		Title:    "Amazing Grace",
+		Artist:   "John Newton",
+		Year:     1779,
+		Key:      "G",
+		Tempo:    72,
+		Time:     "3/4",
+		Tags:     []string{"hymn", "classic"},
+		Sections: []string{"Verse 1", "Verse 2"},
+		Chords:   []string{"G", "G7", "C", "D"},
+		Lyrics:   []string{"Amazing grace how sweet the sound", "That saved a wretch like me"},
+	},
+	{
+		Path:     "blues.txt",
+		Title:    "Flat Blues",
+		Artist:   "Anonymous",
+		Key:      "Gb",
+		Tempo:    120,
+		Capo:     1,
+		Sections: []string{"Verse", "Pre-Chorus 2"},
+		Chords:   []string{"Gb7", "Cb7", "Db7"},
+		Lyrics:   []string{"Woke up this morning, grace was gone"},
+	},
+	{
+		Path:     "sad.txt",
+		Title:    "Sad Song",
+		Key:      "Em",
+		Sections: []string{"Verse", "Bridge"},
+		Chords:   []string{"Em", "C", "G", "Dsus4"},
+	},
+}
+
+func TestQuery_Search(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected []string
+	}{
+		{query: "key:G", expected: []string{"Amazing Grace"}},
+		{query: "key:F#", expected: []string{"Flat Blues"}},
+		{query: "key:em", expected: []string{"Sad Song"}},
+		{query: "chord:B7", expected: []string{"Flat Blues"}},
+		{query: "chord:Dsus", expected: []string{"Sad Song"}},
+		{query: "chord:C", expected: []string{"Amazing Grace", "Sad Song"}},
+		{query: "section:bridge", expected: []string{"Sad Song"}},
+		{query: `section:"pre-chorus 2"`, expected: []string{"Flat Blues"}},
+		{query: "artist:newton", expected: []string{"Amazing Grace"}},
+		{query: "tag:hymn", expected: []string{"Amazing Grace"}},
+		{query: "tempo:>100", expected: []string{"Flat Blues"}},
+		{query: "tempo:<100", expected: []string{"Amazing Grace"}},
+		{query: "tempo:60..80", expected: []string{"Amazing Grace"}},
+		{query: "tempo:=72", expected: []string{"Amazing Grace"}},
+		{query: "year:<=1800", expected: []string{"Amazing Grace"}},
+		{query: "has:capo", expected: []string{"Flat Blues"}},
+		{query: "-has:lyrics", expected: []string{"Sad Song"}},
+		{query: "-key:G", expected: []string{"Flat Blues", "Sad Song"}},
+		{query: "chord:G -tag:hymn", expected: []string{"Sad Song"}},
+		{query: "song", expected: []string{"Sad Song"}},
+		{query: "grace", expected: []string{"Amazing Grace", "Flat Blues"}},
+		{query: "missing", expected: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			th := theory.Default()
+			q, err := library.ParseQuery(th, th, tc.query)
+			require.Nil(t, err)
+
+			var actual []string
+			for _, r := range q.Search(testSongs) {
+				actual = append(actual, r.Song.Title)
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestQuery_Score(t *testing.T) {
+	th := theory.Default()
+	q, err := library.ParseQuery(th, th, "grace")
+	require.Nil(t, err)
+
+	results := q.Search(testSongs)
+	require.Len(t, results, 2)
+	require.Greater(t, results[0].Score, results[1].Score)
+}
+
+func TestQuery_IsEmpty(t *testing.T) {
+	th := theory.Default()
+	q, err := library.ParseQuery(th, th, "  ")
+	require.Nil(t, err)
+	require.True(t, q.IsEmpty())
+	require.False(t, q.HasFields())
+
+	q, err = library.ParseQuery(th, th, "grace key:G")
+	require.Nil(t, err)
+	require.False(t, q.IsEmpty())
+	require.True(t, q.HasFields())
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	testCases := []string{
+		"key:",
+		"mood:happy",
+		"has:mood",
+		"tempo:fast",
+		"tempo:1..x",
+		"tempo:!100",
+		`section:"bridge`,
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc, func(t *testing.T) {
+			th := theory.Default()
+			_, err := library.ParseQuery(th, th, tc)
+			require.NotNil(t, err)
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a problem found in a song.
+type Diagnostic struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Column   int      `json:"column"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s", d.File, d.Line, d.Column, d.Severity, d.Message)
+}
+
+type Config struct {
+	// AllowedSections are the names sections may have, ignoring case and any trailing number. When empty, any name
+	// is allowed.
+	AllowedSections []string
+}
+
+// Lint reads the song and checks it for problems, returning the diagnostics ordered by their position.
+func Lint(th *theory.Theory, file string, r io.Reader, cfg Config) ([]Diagnostic, error) {
+	src := songio.ReadChordsOverLyrics(th, th, r)
+	lines, err := songio.ReadAllLines(src)
+	if err != nil {
+		return nil, err
+	}
+
+	l := linter{
+		theory: th,
+		file:   file,
+		cfg:    cfg,
+	}
+
+	for _, ce := range src.ChordErrors() {
+		l.report(ce.Range.Start, ce.Range.Start.Column, SeverityError, "%q is not a valid chord, so the line was read as lyrics", ce.Text)
+	}
+
+	// Each song in a songbook is checked on its own.
+	songs := songio.ReadSongs(songio.FromLines(lines))
+	for song, ok := songs.Next(); ok; song, ok = songs.Next() {
+		l.lint(song.Lines())
+	}
+
+	sort.SliceStable(l.diagnostics, func(i, j int) bool {
+		if l.diagnostics[i].Line != l.diagnostics[j].Line {
+			return l.diagnostics[i].Line < l.diagnostics[j].Line
+		}
+		return l.diagnostics[i].Column < l.diagnostics[j].Column
+	})
+
+	return l.diagnostics, nil
+}
+
+// CountErrors counts the diagnostics that are errors.
+func CountErrors(diagnostics []Diagnostic) int {
+	count := 0
+	for _, d := range diagnostics {
+		if d.Severity == SeverityError {
+			count++
+		}
+	}
+
+	return count
+}
+
+type linter struct {
+	theory *theory.Theory
+	file   string
+	cfg    Config
+
+	diagnostics []Diagnostic
+}
+
+func (l *linter) report(pos songio.Position, column int, severity Severity, format string, args ...interface{}) {
+	l.diagnostics = append(l.diagnostics, Diagnostic{
+		File:     l.file,
+		Line:     pos.Line,
+		Column:   column,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) lint(lines []songio.Line) {
+	var (
+		title        *songio.TitleDirectiveLine
+		keyLine      *songio.KeyDirectiveLine
+		chords       []chord.Chord
+		sectionStart *songio.SectionStartDirectiveLine
+		sectionLines int
+		orderLine    *songio.OrderDirectiveLine
+		defined      []string
+		empty        []*songio.SectionStartDirectiveLine
+	)
+
+	for i, line := range lines {
+		pos := songio.PositionOf(line)
+		switch tl := line.(type) {
+		case *songio.TitleDirectiveLine:
+			if title != nil {
+				l.report(pos, 1, SeverityError, "duplicate #title; already set to %q on line %d", title.Title, title.Pos.Line)
+			} else {
+				title = tl
+			}
+		case *songio.KeyDirectiveLine:
+			if keyLine == nil {
+				keyLine = tl
+			}
+		case *songio.OrderDirectiveLine:
+			if orderLine != nil {
+				l.report(pos, 1, SeverityError, "duplicate #order; already set on line %d", orderLine.Pos.Line)
+			} else {
+				orderLine = tl
+			}
+		case *songio.UnknownDirectiveLine:
+			if songio.IsKnownDirective(tl.Name) {
+				// Known directives are only read as unknown when their values are invalid.
+				l.report(pos, 1, SeverityError, "invalid %s %q", tl.Name, tl.Value)
+			} else {
+				l.report(pos, 1, SeverityWarning, "unknown directive %q", tl.Name)
+			}
+		case *songio.SectionStartDirectiveLine:
+			sectionStart = tl
+			sectionLines = 0
+			if !l.isAllowedSection(tl.Name) {
+				l.report(pos, 2, SeverityWarning, "section %q is not one of the allowed sections", tl.Name)
+			}
+		case *songio.SectionEndDirectiveLine:
+			if sectionStart != nil {
+				if sectionLines == 0 {
+					empty = append(empty, sectionStart)
+				} else {
+					defined = append(defined, sectionStart.Name)
+				}
+			}
+			sectionStart = nil
+		case *songio.ChordLine:
+			sectionLines++
+			for _, co := range tl.Chords {
+				chords = append(chords, co.Chord.Chord)
+			}
+
+			if lyrics, ok := lyricsAfter(lines[i+1:]); ok {
+				l.lintChordPositions(tl, lyrics)
+			}
+		case *songio.TextLine:
+			sectionLines++
+		}
+	}
+
+	// An empty section repeats the section of the same name.
+	for _, ssdl := range empty {
+		if !containsFold(defined, ssdl.Name) {
+			l.report(ssdl.Pos, 2, SeverityWarning, "section %q is empty", ssdl.Name)
+		}
+	}
+
+	if orderLine != nil {
+		for _, entry := range orderLine.Order {
+			if _, ok := songio.ResolveSection(defined, entry); !ok {
+				l.report(orderLine.Pos, 1, SeverityError, "#order refers to %q, which does not match a section", entry)
+			}
+		}
+	}
+
+	if title == nil {
+		start := songio.Position{Line: 1}
+		if len(lines) > 0 && songio.PositionOf(lines[0]).IsValid() {
+			start = songio.PositionOf(lines[0])
+		}
+		l.report(start, 1, SeverityWarning, "missing #title")
+	}
+
+	if keyLine != nil {
+		l.lintKey(keyLine, chords)
+	}
+}
+
+// lyricsAfter finds the lyrics that chords are played over at the start of the lines, skipping any blank lines and
+// comments before them.
+func lyricsAfter(lines []songio.Line) (*songio.TextLine, bool) {
+	for _, line := range lines {
+		switch tl := line.(type) {
+		case songio.EmptyLine, *songio.CommentDirectiveLine:
+			continue
+		case *songio.TextLine:
+			return tl, true
+		}
+		return nil, false
+	}
+
+	return nil, false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l *linter) isAllowedSection(name string) bool {
+	if len(l.cfg.AllowedSections) == 0 {
+		return true
+	}
+
+	name = strings.TrimSpace(strings.TrimRightFunc(name, unicode.IsDigit))
+	for _, allowed := range l.cfg.AllowedSections {
+		if strings.EqualFold(name, allowed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l *linter) lintChordPositions(cl *songio.ChordLine, lyrics *songio.TextLine) {
+	length := utf8.RuneCountInString(strings.TrimRightFunc(lyrics.Text, unicode.IsSpace))
+	for _, co := range cl.Chords {
+		if co.Offset >= length {
+			l.report(cl.Pos, co.Range.Start.Column, SeverityWarning, "chord %q is positioned beyond the end of the lyrics", co.Chord.Name)
+		}
+	}
+}
+
+func (l *linter) lintKey(kl *songio.KeyDirectiveLine, chords []chord.Chord) {
+	detected, ok := harmony.DetectKey(chords)
+	if !ok || sameKey(detected, kl.Key.Key) {
+		return
+	}
+
+	if harmony.ScoreKey(kl.Key.Key, chords) < harmony.ScoreKey(detected, chords) {
+		l.report(kl.Pos, 1, SeverityWarning, "#key=%s is inconsistent with the chords, which suggest %s", kl.Key.Name, l.theory.NameKey(detected))
+	}
+}
+
+func sameKey(a, b key.Key) bool {
+	return a.Kind() == b.Kind() && a.Note().PitchClass() == b.Note().PitchClass()
+}
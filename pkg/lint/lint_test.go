@@ -0,0 +1,96 @@
+package lint_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/lint"
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		cfg      lint.Config
+		expected []string
+	}{
+		{
+			name:     "clean",
+			text:     "#title=One\n#key=G\n[Verse]\nG   D\nla la\n",
+			expected: nil,
+		},
+		{
+			name:     "missing title",
+			text:     "[Verse]\nla\n",
+			expected: []string{"song:1:1: warning: missing #title"},
+		},
+		{
+			name:     "duplicate title",
+			text:     "#title=One\n[Verse]\nla\n#title=Two\n",
+			expected: []string{`song:4:1: error: duplicate #title; already set to "One" on line 1`},
+		},
+		{
+			name:     "duplicate title in songbook",
+			text:     "#title=One\n---\n#title=Two\n#title=Three\n",
+			expected: []string{`song:4:1: error: duplicate #title; already set to "Two" on line 3`},
+		},
+		{
+			name: "order",
+			text: "#title=One\n#order=Verse, Chorus, Bridge\n[Verse]\nla\n[Chorus]\nlo\n",
+			expected: []string{
+				`song:2:1: error: #order refers to "Bridge", which does not match a section`,
+			},
+		},
+		{
+			name:     "empty section repeats",
+			text:     "#title=One\n[Chorus]\nla\n\n[Chorus]\n\n[Bridge]\n",
+			expected: []string{`song:7:2: warning: section "Bridge" is empty`},
+		},
+		{
+			name:     "invalid known directive",
+			text:     "#title=One\n#tempo=fast\n",
+			expected: []string{`song:2:1: error: invalid tempo "fast"`},
+		},
+		{
+			name:     "unknown directive",
+			text:     "#title=One\n#mood=happy\n",
+			expected: []string{`song:2:1: warning: unknown directive "mood"`},
+		},
+		{
+			name:     "allowed sections",
+			text:     "#title=One\n[Verse 1]\nla\n[Outro]\nlo\n",
+			cfg:      lint.Config{AllowedSections: []string{"verse", "chorus"}},
+			expected: []string{`song:4:2: warning: section "Outro" is not one of the allowed sections`},
+		},
+		{
+			name:     "chord beyond lyrics",
+			text:     "#title=One\nG      D\nla\n",
+			expected: []string{`song:2:8: warning: chord "D" is positioned beyond the end of the lyrics`},
+		},
+		{
+			name:     "chord beyond lyrics after a blank line and comment",
+			text:     "#title=One\nG      D\n\n#comment=softly\nla\n",
+			expected: []string{`song:2:8: warning: chord "D" is positioned beyond the end of the lyrics`},
+		},
+		{
+			name:     "chords without lyrics",
+			text:     "#title=One\n[Intro]\nG      D\n\n[Verse]\nla\n",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			diagnostics, err := lint.Lint(theory.Default(), "song", strings.NewReader(tc.text), tc.cfg)
+			require.Nil(t, err)
+
+			var actual []string
+			for _, d := range diagnostics {
+				actual = append(actual, d.String())
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package setlist
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
+	"github.com/knadh/koanf"
+	koanfjson "github.com/knadh/koanf/parsers/json"
+	"github.com/knadh/koanf/parsers/toml"
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/file"
+)
+
+// Setlist is the songs to perform, in order.
+type Setlist struct {
+	Title string  `json:"title"`
+	Songs []Entry `json:"songs"`
+
+	// Dir is the directory that the paths of the songs are relative to, which is the setlist's directory.
+	Dir string `json:"-"`
+}
+
+// Entry is a song in a setlist along with how it is to be played.
+type Entry struct {
+	Path string `json:"path"`
+	// Song chooses the song from a file with more than one song, by its number, starting at 1, or its title.
+	Song string `json:"song,omitempty"`
+	// Key is the key the song is to sound in. When it is empty, Transpose is the number of half steps to move the song
+	// from the key it is written in.
+	Key       string `json:"key,omitempty"`
+	Transpose int    `json:"transpose,omitempty"`
+	// Capo is the fret the capo is placed on. The chords are written for the capo, so they sound in the song's key.
+	Capo  int    `json:"capo,omitempty"`
+	Notes string `json:"notes,omitempty"`
+}
+
+// Load reads the setlist at the path, which may be YAML, TOML, or JSON.
+func Load(path string) (*Setlist, error) {
+	k := koanf.New(".")
+
+	var err error
+	switch ext := filepath.Ext(path); ext {
+	case ".json":
+		err = k.Load(file.Provider(path), koanfjson.Parser())
+	case ".yaml", ".yml":
+		err = k.Load(file.Provider(path), yaml.Parser())
+	case ".toml":
+		err = k.Load(file.Provider(path), toml.Parser())
+	default:
+		return nil, fmt.Errorf("unsupported setlist format %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading setlist at %q: %w", path, err)
+	}
+
+	var s Setlist
+	if err := k.UnmarshalWithConf("", &s, koanf.UnmarshalConf{Tag: "json"}); err != nil {
+		return nil, fmt.Errorf("reading setlist at %q: %w", path, err)
+	}
+
+	for i, entry := range s.Songs {
+		if len(entry.Path) == 0 {
+			return nil, fmt.Errorf("reading setlist at %q: song %d has no path", path, i+1)
+		}
+		if entry.Capo < 0 {
+			return nil, fmt.Errorf("reading setlist at %q: song %d has a negative capo", path, i+1)
+		}
+	}
+
+	s.Dir = filepath.Dir(path)
+	return &s, nil
+}
+
+// Path returns the path of the entry's song, resolved against the setlist's directory.
+func (s *Setlist) Path(entry Entry) string {
+	if filepath.IsAbs(entry.Path) {
+		return entry.Path
+	}
+
+	return filepath.Join(s.Dir, entry.Path)
+}
+
+// Song is a song from a setlist, ready to be played.
+type Song struct {
+	Entry
+
+	// Title is the title of the song, or the name of its file when it has none.
+	Title string
+	// Written is the key the song is written in, and Key is the key it is to sound in. Shape is the key its chords are
+	// written in for the capo. They are nil when the song has no key.
+	Written *key.Named
+	Key     *key.Named
+	Shape   *key.Named
+
+	Lines []songio.Line
+}
+
+// Open reads the song at the index in the setlist, transposed so that it sounds in its key when played with its capo.
+// A capo directive in the song is replaced with the setlist's capo, and the setlist's notes are added as a comment.
+func (s *Setlist) Open(th *theory.Theory, index int) (*Song, error) {
+	if index < 0 || index >= len(s.Songs) {
+		return nil, fmt.Errorf("setlist has no song %d", index+1)
+	}
+
+	entry := s.Songs[index]
+	path := s.Path(entry)
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var rdr songio.Reader = songio.ReadChordsOverLyrics(th, th, f)
+	if len(entry.Song) > 0 {
+		rdr = songio.SelectSong(rdr, entry.Song)
+	}
+
+	lines, err := songio.ReadAllLines(rdr)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %w", path, err)
+	}
+
+	meta, err := songio.ReadMeta(th, songio.FromLines(lines), true)
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %w", path, err)
+	}
+
+	song := &Song{
+		Entry:   entry,
+		Title:   meta.Title,
+		Written: meta.Key,
+	}
+	if len(song.Title) == 0 {
+		song.Title = filepath.Base(path)
+	}
+
+	if meta.Key != nil {
+		written := *meta.Key
+
+		// A capo in the song means its chords sound higher than they are written.
+		sounding := written.Transpose(th, written.Note().Step(meta.Capo))
+		switch {
+		case len(entry.Key) > 0:
+			target, err := th.ParseKey(entry.Key)
+			if err != nil {
+				return nil, fmt.Errorf("song %d has an invalid key: %w", index+1, err)
+			}
+			sounding = sounding.Transpose(th, sounding.Note().Interval(target.Note()))
+		case entry.Transpose != 0:
+			sounding = sounding.Transpose(th, sounding.Note().Step(entry.Transpose))
+		}
+
+		shape := sounding.Transpose(th, sounding.Note().Step(-entry.Capo))
+		lines, err = songio.ReadAllLines(songio.Transpose(th, songio.FromLines(lines), written.Note().Interval(shape.Note())))
+		if err != nil {
+			return nil, err
+		}
+
+		song.Key = &sounding
+		song.Shape = &shape
+	} else if len(entry.Key) > 0 || entry.Transpose != 0 {
+		// Without a key, a capo leaves the chords as they are written.
+		return nil, fmt.Errorf("song %d, %q, has no key to transpose from", index+1, song.Title)
+	}
+
+	song.Lines = annotate(lines, entry)
+	return song, nil
+}
+
+// annotate replaces the capo directives in the lines with the entry's capo and adds its notes as a comment, after the
+// title.
+func annotate(lines []songio.Line, entry Entry) []songio.Line {
+	var added []songio.Line
+	if entry.Capo > 0 {
+		added = append(added, &songio.CapoDirectiveLine{Fret: entry.Capo})
+	}
+	if len(entry.Notes) > 0 {
+		added = append(added, &songio.CommentDirectiveLine{Comment: entry.Notes})
+	}
+
+	at := 0
+	result := make([]songio.Line, 0, len(lines)+len(added))
+	for _, line := range lines {
+		switch line.(type) {
+		case *songio.CapoDirectiveLine:
+			continue
+		case *songio.TitleDirectiveLine:
+			at = len(result) + 1
+		}
+
+		result = append(result, line)
+	}
+
+	result = append(result[:at], append(added, result[at:]...)...)
+	return result
+}
@@ -0,0 +1,237 @@
+package setlist_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/setlist"
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	testCases := []struct {
+		name     string
+		file     string
+		text     string
+		expected []setlist.Entry
+		err      bool
+	}{
+		{
+			name:     "yaml",
+			file:     "set.yaml",
+			text:     "title: Sunday\nsongs:\n  - path: one.txt\n    key: A\n    capo: 2\n  - path: two.txt\n    transpose: -1\n    notes: slow\n",
+			expected: []setlist.Entry{{Path: "one.txt", Key: "A", Capo: 2}, {Path: "two.txt", Transpose: -1, Notes: "slow"}},
+		},
+		{
+			name:     "toml",
+			file:     "set.toml",
+			text:     "title = \"Sunday\"\n[[songs]]\npath = \"one.txt\"\nsong = \"2\"\n",
+			expected: []setlist.Entry{{Path: "one.txt", Song: "2"}},
+		},
+		{
+			name:     "json",
+			file:     "set.json",
+			text:     `{"title": "Sunday", "songs": [{"path": "one.txt", "key": "Bb"}]}`,
+			expected: []setlist.Entry{{Path: "one.txt", Key: "Bb"}},
+		},
+		{
+			name: "unsupported format",
+			file: "set.txt",
+			text: "one.txt\n",
+			err:  true,
+		},
+		{
+			name: "song without a path",
+			file: "set.yaml",
+			text: "songs:\n  - key: A\n",
+			err:  true,
+		},
+		{
+			name: "negative capo",
+			file: "set.yaml",
+			text: "songs:\n  - path: one.txt\n    capo: -1\n",
+			err:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, tc.file)
+			require.Nil(t, os.WriteFile(path, []byte(tc.text), 0644))
+
+			actual, err := setlist.Load(path)
+			if tc.err {
+				require.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, "Sunday", actual.Title)
+			require.Equal(t, tc.expected, actual.Songs)
+			require.Equal(t, dir, actual.Dir)
+		})
+	}
+}
+
+func TestSetlist_Path(t *testing.T) {
+	s := setlist.Setlist{Dir: filepath.Join("sets", "sunday")}
+
+	require.Equal(t, filepath.Join("sets", "sunday", "songs", "one.txt"), s.Path(setlist.Entry{Path: filepath.Join("songs", "one.txt")}))
+	require.Equal(t, filepath.Join("sets", "two.txt"), s.Path(setlist.Entry{Path: filepath.Join("..", "two.txt")}))
+
+	abs, err := filepath.Abs("three.txt")
+	require.Nil(t, err)
+	require.Equal(t, abs, s.Path(setlist.Entry{Path: abs}))
+}
+
+func TestSetlist_Open(t *testing.T) {
+	testCases := []struct {
+		name    string
+		song    string
+		entry   setlist.Entry
+		written string
+		key     string
+		shape   string
+		text    string
+		err     bool
+	}{
+		{
+			name:    "as written",
+			song:    "#title=One\n#key=G\nG   D\nla la\n",
+			written: "G",
+			key:     "G",
+			shape:   "G",
+			text:    "#title=One\n#key=G\nG   D\nla la\n",
+		},
+		{
+			name:    "to a key",
+			song:    "#title=One\n#key=G\nG   D\nla la\n",
+			entry:   setlist.Entry{Key: "A"},
+			written: "G",
+			key:     "A",
+			shape:   "A",
+			text:    "#title=One\n#key=A\nA   E\nla la\n",
+		},
+		{
+			name:    "by half steps",
+			song:    "#title=One\n#key=G\nG   D\nla la\n",
+			entry:   setlist.Entry{Transpose: -2},
+			written: "G",
+			key:     "F",
+			shape:   "F",
+			text:    "#title=One\n#key=F\nF   C\nla la\n",
+		},
+		{
+			name:    "with a capo",
+			song:    "#title=One\n#key=G\nG   D\nla la\n",
+			entry:   setlist.Entry{Key: "A", Capo: 2, Notes: "start soft"},
+			written: "G",
+			key:     "A",
+			shape:   "G",
+			text:    "#title=One\n#capo=2\n#comment=start soft\n#key=G\nG   D\nla la\n",
+		},
+		{
+			name:    "with a capo in the song",
+			song:    "#title=One\n#capo=2\n#key=G\nG   D\nla la\n",
+			entry:   setlist.Entry{Capo: 4},
+			written: "G",
+			key:     "A",
+			shape:   "F",
+			text:    "#title=One\n#capo=4\n#key=F\nF   C\nla la\n",
+		},
+		{
+			name:    "removes the song's capo",
+			song:    "#title=One\n#capo=2\n#key=G\nG   D\nla la\n",
+			written: "G",
+			key:     "A",
+			shape:   "A",
+			text:    "#title=One\n#key=A\nA   E\nla la\n",
+		},
+		{
+			name:  "without a key",
+			song:  "#title=One\nla la\n",
+			entry: setlist.Entry{Capo: 2},
+			text:  "#title=One\n#capo=2\nla la\n",
+		},
+		{
+			name:  "without a key to transpose",
+			song:  "#title=One\nla la\n",
+			entry: setlist.Entry{Key: "A"},
+			err:   true,
+		},
+		{
+			name:  "with an invalid key",
+			song:  "#title=One\n#key=G\nG   D\nla la\n",
+			entry: setlist.Entry{Key: "H"},
+			err:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.Nil(t, os.MkdirAll(filepath.Join(dir, "songs"), 0755))
+			require.Nil(t, os.WriteFile(filepath.Join(dir, "songs", "one.txt"), []byte(tc.song), 0644))
+
+			tc.entry.Path = filepath.Join("songs", "one.txt")
+			s := &setlist.Setlist{Songs: []setlist.Entry{tc.entry}, Dir: dir}
+
+			th := theory.Default()
+			song, err := s.Open(th, 0)
+			if tc.err {
+				require.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, "One", song.Title)
+			require.Equal(t, tc.written, keyName(song.Written))
+			require.Equal(t, tc.key, keyName(song.Key))
+			require.Equal(t, tc.shape, keyName(song.Shape))
+
+			var sb strings.Builder
+			_, err = songio.WriteChordsOverLyrics(th, songio.FromLines(song.Lines), &sb)
+			require.Nil(t, err)
+			require.Equal(t, tc.text, sb.String())
+		})
+	}
+}
+
+func TestSetlist_Open_Title(t *testing.T) {
+	dir := t.TempDir()
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "untitled.txt"), []byte("la\n"), 0644))
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "book.txt"), []byte("#title=One\nla\n---\n#title=Two\nlo\n"), 0644))
+
+	s := &setlist.Setlist{
+		Songs: []setlist.Entry{{Path: "untitled.txt"}, {Path: "book.txt", Song: "Two"}, {Path: "missing.txt"}},
+		Dir:   dir,
+	}
+
+	song, err := s.Open(theory.Default(), 0)
+	require.Nil(t, err)
+	require.Equal(t, "untitled.txt", song.Title)
+
+	song, err = s.Open(theory.Default(), 1)
+	require.Nil(t, err)
+	require.Equal(t, "Two", song.Title)
+
+	_, err = s.Open(theory.Default(), 2)
+	require.NotNil(t, err)
+
+	_, err = s.Open(theory.Default(), 3)
+	require.NotNil(t, err)
+}
+
+func keyName(k *key.Named) string {
+	if k == nil {
+		return ""
+	}
+
+	return k.Name
+}
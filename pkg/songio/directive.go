@@ -2,12 +2,151 @@ package songio
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/craiggwilson/songtool/pkg/theory/key"
 )
 
+// AlbumDirectiveLine names the album the song was released on.
+type AlbumDirectiveLine struct {
+	Album string `json:"album"`
+
+	Pos Position `json:"-"`
+}
+
+func (d *AlbumDirectiveLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Directive string `json:"directive"`
+		Value     string `json:"value"`
+	}{
+		Directive: "album",
+		Value:     d.Album,
+	})
+}
+
+func (d *AlbumDirectiveLine) line() {}
+
+// ArtistDirectiveLine names the artist who wrote or performs the song.
+type ArtistDirectiveLine struct {
+	Artist string `json:"artist"`
+
+	Pos Position `json:"-"`
+}
+
+func (d *ArtistDirectiveLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Directive string `json:"directive"`
+		Value     string `json:"value"`
+	}{
+		Directive: "artist",
+		Value:     d.Artist,
+	})
+}
+
+func (d *ArtistDirectiveLine) line() {}
+
+// CapoDirectiveLine is the fret the capo is placed on. The chords are written as they are fingered.
+type CapoDirectiveLine struct {
+	Fret int `json:"fret"`
+
+	Pos Position `json:"-"`
+}
+
+func (d *CapoDirectiveLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Directive string `json:"directive"`
+		Value     int    `json:"value"`
+	}{
+		Directive: "capo",
+		Value:     d.Fret,
+	})
+}
+
+func (d *CapoDirectiveLine) line() {}
+
+// CCLIDirectiveLine is the song's number with Christian Copyright Licensing International.
+type CCLIDirectiveLine struct {
+	Number string `json:"number"`
+
+	Pos Position `json:"-"`
+}
+
+func (d *CCLIDirectiveLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Directive string `json:"directive"`
+		Value     string `json:"value"`
+	}{
+		Directive: "ccli",
+		Value:     d.Number,
+	})
+}
+
+func (d *CCLIDirectiveLine) line() {}
+
+// CommentDirectiveLine is a note for the performers, such as "softly" or "build".
+type CommentDirectiveLine struct {
+	Comment string `json:"comment"`
+
+	Pos Position `json:"-"`
+}
+
+func (d *CommentDirectiveLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Directive string `json:"directive"`
+		Value     string `json:"value"`
+	}{
+		Directive: "comment",
+		Value:     d.Comment,
+	})
+}
+
+func (d *CommentDirectiveLine) line() {}
+
+// CopyrightDirectiveLine is the song's copyright notice.
+type CopyrightDirectiveLine struct {
+	Copyright string `json:"copyright"`
+
+	Pos Position `json:"-"`
+}
+
+func (d *CopyrightDirectiveLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Directive string `json:"directive"`
+		Value     string `json:"value"`
+	}{
+		Directive: "copyright",
+		Value:     d.Copyright,
+	})
+}
+
+func (d *CopyrightDirectiveLine) line() {}
+
+// DurationDirectiveLine is how long the song takes to perform, written as minutes and seconds, such as "3:45".
+type DurationDirectiveLine struct {
+	Duration time.Duration `json:"duration"`
+
+	Pos Position `json:"-"`
+}
+
+func (d *DurationDirectiveLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Directive string `json:"directive"`
+		Value     string `json:"value"`
+	}{
+		Directive: "duration",
+		Value:     FormatDuration(d.Duration),
+	})
+}
+
+func (d *DurationDirectiveLine) line() {}
+
 type KeyDirectiveLine struct {
 	Key key.Named `json:"key"`
+
+	Pos Position `json:"-"`
 }
 
 func (d *KeyDirectiveLine) MarshalJSON() ([]byte, error) {
@@ -22,8 +161,29 @@ func (d *KeyDirectiveLine) MarshalJSON() ([]byte, error) {
 
 func (d *KeyDirectiveLine) line() {}
 
+// OrderDirectiveLine lists the sections in the order they are performed, by name or abbreviation, such as "V1 C V2 C".
+type OrderDirectiveLine struct {
+	Order []string `json:"order"`
+
+	Pos Position `json:"-"`
+}
+
+func (d *OrderDirectiveLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Directive string   `json:"directive"`
+		Value     []string `json:"value"`
+	}{
+		Directive: "order",
+		Value:     d.Order,
+	})
+}
+
+func (d *OrderDirectiveLine) line() {}
+
 type SectionEndDirectiveLine struct {
 	Name string `json:"name"`
+
+	Pos Position `json:"-"`
 }
 
 func (d *SectionEndDirectiveLine) MarshalJSON() ([]byte, error) {
@@ -37,6 +197,8 @@ func (d *SectionEndDirectiveLine) line() {}
 
 type SectionStartDirectiveLine struct {
 	Name string `json:"name"`
+
+	Pos Position `json:"-"`
 }
 
 func (d *SectionStartDirectiveLine) MarshalJSON() ([]byte, error) {
@@ -48,8 +210,67 @@ func (d *SectionStartDirectiveLine) MarshalJSON() ([]byte, error) {
 
 func (d *SectionStartDirectiveLine) line() {}
 
+// TagsDirectiveLine lists the tags used to group songs, such as "christmas" or "upbeat", separated by commas.
+type TagsDirectiveLine struct {
+	Tags []string `json:"tags"`
+
+	Pos Position `json:"-"`
+}
+
+func (d *TagsDirectiveLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Directive string   `json:"directive"`
+		Value     []string `json:"value"`
+	}{
+		Directive: "tags",
+		Value:     d.Tags,
+	})
+}
+
+func (d *TagsDirectiveLine) line() {}
+
+// TempoDirectiveLine is the tempo of the song in beats per minute.
+type TempoDirectiveLine struct {
+	BPM int `json:"bpm"`
+
+	Pos Position `json:"-"`
+}
+
+func (d *TempoDirectiveLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Directive string `json:"directive"`
+		Value     int    `json:"value"`
+	}{
+		Directive: "tempo",
+		Value:     d.BPM,
+	})
+}
+
+func (d *TempoDirectiveLine) line() {}
+
+// TimeDirectiveLine is the song's time signature.
+type TimeDirectiveLine struct {
+	Time TimeSignature `json:"time"`
+
+	Pos Position `json:"-"`
+}
+
+func (d *TimeDirectiveLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Directive string `json:"directive"`
+		Value     string `json:"value"`
+	}{
+		Directive: "time",
+		Value:     d.Time.String(),
+	})
+}
+
+func (d *TimeDirectiveLine) line() {}
+
 type TitleDirectiveLine struct {
 	Title string `json:"title"`
+
+	Pos Position `json:"-"`
 }
 
 func (d *TitleDirectiveLine) MarshalJSON() ([]byte, error) {
@@ -64,6 +285,138 @@ func (d *TitleDirectiveLine) line() {}
 type UnknownDirectiveLine struct {
 	Name  string `json:"directive"`
 	Value string `json:"value,omitempty"`
+
+	Pos Position `json:"-"`
 }
 
 func (d *UnknownDirectiveLine) line() {}
+
+// YearDirectiveLine is the year the song was released.
+type YearDirectiveLine struct {
+	Year int `json:"year"`
+
+	Pos Position `json:"-"`
+}
+
+func (d *YearDirectiveLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Directive string `json:"directive"`
+		Value     int    `json:"value"`
+	}{
+		Directive: "year",
+		Value:     d.Year,
+	})
+}
+
+func (d *YearDirectiveLine) line() {}
+
+// TimeSignature is the number of beats in a bar and the note value that gets a beat, such as 6/8.
+type TimeSignature struct {
+	Beats int `json:"beats"`
+	Value int `json:"value"`
+}
+
+// ParseTimeSignature parses a time signature written as beats over note value, such as "3/4".
+func ParseTimeSignature(text string) (TimeSignature, error) {
+	idx := strings.IndexRune(text, '/')
+	if idx < 0 {
+		return TimeSignature{}, fmt.Errorf("time signature %q must be written as beats/value", text)
+	}
+
+	beats, err := strconv.Atoi(strings.TrimSpace(text[:idx]))
+	if err != nil || beats <= 0 {
+		return TimeSignature{}, fmt.Errorf("time signature %q has invalid beats", text)
+	}
+
+	value, err := strconv.Atoi(strings.TrimSpace(text[idx+1:]))
+	if err != nil || value <= 0 || value&(value-1) != 0 {
+		return TimeSignature{}, fmt.Errorf("time signature %q has an invalid note value", text)
+	}
+
+	return TimeSignature{Beats: beats, Value: value}, nil
+}
+
+func (ts TimeSignature) String() string {
+	return fmt.Sprintf("%d/%d", ts.Beats, ts.Value)
+}
+
+// ParseDuration parses a duration written as minutes and seconds, such as "3:45", or as a Go duration, such as "3m45s".
+func ParseDuration(text string) (time.Duration, error) {
+	idx := strings.IndexRune(text, ':')
+	if idx < 0 {
+		d, err := time.ParseDuration(text)
+		if err != nil || d <= 0 {
+			return 0, fmt.Errorf("duration %q must be written as minutes:seconds", text)
+		}
+		return d, nil
+	}
+
+	minutes, err := strconv.Atoi(strings.TrimSpace(text[:idx]))
+	if err != nil || minutes < 0 {
+		return 0, fmt.Errorf("duration %q has invalid minutes", text)
+	}
+
+	secondsText := strings.TrimSpace(text[idx+1:])
+	seconds, err := strconv.Atoi(secondsText)
+	if err != nil || seconds < 0 || seconds >= 60 || len(secondsText) != 2 {
+		return 0, fmt.Errorf("duration %q has invalid seconds", text)
+	}
+
+	return time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second, nil
+}
+
+// FormatDuration writes the duration as minutes and seconds, such as "3:45".
+func FormatDuration(d time.Duration) string {
+	seconds := int(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// Directive returns the name and value of the directive as it is written in a song. It returns false when the line is
+// not a directive; sections, which are written differently, are not directives.
+func Directive(line Line) (name string, value string, ok bool) {
+	switch tl := line.(type) {
+	case *AlbumDirectiveLine:
+		return "album", tl.Album, true
+	case *ArtistDirectiveLine:
+		return "artist", tl.Artist, true
+	case *CapoDirectiveLine:
+		return "capo", strconv.Itoa(tl.Fret), true
+	case *CCLIDirectiveLine:
+		return "ccli", tl.Number, true
+	case *CommentDirectiveLine:
+		return "comment", tl.Comment, true
+	case *CopyrightDirectiveLine:
+		return "copyright", tl.Copyright, true
+	case *DurationDirectiveLine:
+		return "duration", FormatDuration(tl.Duration), true
+	case *KeyDirectiveLine:
+		return "key", tl.Key.Name, true
+	case *OrderDirectiveLine:
+		return "order", formatOrder(tl.Order), true
+	case *TagsDirectiveLine:
+		return "tags", strings.Join(tl.Tags, ", "), true
+	case *TempoDirectiveLine:
+		return "tempo", strconv.Itoa(tl.BPM), true
+	case *TimeDirectiveLine:
+		return "time", tl.Time.String(), true
+	case *TitleDirectiveLine:
+		return "title", tl.Title, true
+	case *UnknownDirectiveLine:
+		return tl.Name, tl.Value, true
+	case *YearDirectiveLine:
+		return "year", strconv.Itoa(tl.Year), true
+	default:
+		return "", "", false
+	}
+}
+
+// IsKnownDirective indicates whether the name is one of the directives that is read into a typed line.
+func IsKnownDirective(name string) bool {
+	switch name {
+	case "album", "arrangement", "artist", "capo", "ccli", "comment", "copyright", "duration", "key", "order", "tags",
+		"tempo", "time", "title", "year":
+		return true
+	default:
+		return false
+	}
+}
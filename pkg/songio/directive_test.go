@@ -0,0 +1,223 @@
+package songio_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderDirective_RoundTrip(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected string
+		order    []string
+	}{
+		{
+			text:     "#order=Verse Chorus Verse Chorus",
+			expected: "#order=Verse Chorus Verse Chorus",
+			order:    []string{"Verse", "Chorus", "Verse", "Chorus"},
+		},
+		{
+			text:     "#order=Verse 1, Chorus, Verse 2, Chorus",
+			expected: "#order=Verse 1, Chorus, Verse 2, Chorus",
+			order:    []string{"Verse 1", "Chorus", "Verse 2", "Chorus"},
+		},
+		{
+			text:     "#order=Verse 1,",
+			expected: "#order=Verse 1,",
+			order:    []string{"Verse 1"},
+		},
+		{
+			text:     "#order=V1,C , V2,C",
+			expected: "#order=V1 C V2 C",
+			order:    []string{"V1", "C", "V2", "C"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			th := theory.Default()
+			lines, err := songio.ReadAllLines(songio.ReadChordsOverLyrics(th, th, strings.NewReader(tc.text)))
+			require.Nil(t, err)
+			require.Len(t, lines, 1)
+
+			odl, ok := lines[0].(*songio.OrderDirectiveLine)
+			require.True(t, ok, "expected an order directive, but got %T", lines[0])
+			require.Equal(t, tc.order, odl.Order)
+
+			var sb strings.Builder
+			_, err = songio.WriteChordsOverLyrics(th, songio.FromLines(lines), &sb)
+			require.Nil(t, err)
+			require.Equal(t, tc.expected+"\n", sb.String())
+
+			reread, err := songio.ReadAllLines(songio.ReadChordsOverLyrics(th, th, strings.NewReader(sb.String())))
+			require.Nil(t, err)
+			require.Equal(t, tc.order, reread[0].(*songio.OrderDirectiveLine).Order)
+		})
+	}
+}
+
+func TestTypedDirectives_RoundTrip(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected string
+		line     songio.Line
+	}{
+		{
+			text:     "#tempo=120",
+			expected: "#tempo=120",
+			line:     &songio.TempoDirectiveLine{},
+		},
+		{
+			text:     "#tempo=96 BPM",
+			expected: "#tempo=96",
+			line:     &songio.TempoDirectiveLine{},
+		},
+		{
+			text:     "#tempo=fast",
+			expected: "#tempo=fast",
+			line:     &songio.UnknownDirectiveLine{},
+		},
+		{
+			text:     "#tempo=0",
+			expected: "#tempo=0",
+			line:     &songio.UnknownDirectiveLine{},
+		},
+		{
+			text:     "#time=6 / 8",
+			expected: "#time=6/8",
+			line:     &songio.TimeDirectiveLine{},
+		},
+		{
+			text:     "#time=4/3",
+			expected: "#time=4/3",
+			line:     &songio.UnknownDirectiveLine{},
+		},
+		{
+			text:     "#duration=3:45",
+			expected: "#duration=3:45",
+			line:     &songio.DurationDirectiveLine{},
+		},
+		{
+			text:     "#duration=4m5s",
+			expected: "#duration=4:05",
+			line:     &songio.DurationDirectiveLine{},
+		},
+		{
+			text:     "#duration=3:75",
+			expected: "#duration=3:75",
+			line:     &songio.UnknownDirectiveLine{},
+		},
+		{
+			text:     "#capo=3",
+			expected: "#capo=3",
+			line:     &songio.CapoDirectiveLine{},
+		},
+		{
+			text:     "#capo=-1",
+			expected: "#capo=-1",
+			line:     &songio.UnknownDirectiveLine{},
+		},
+		{
+			text:     "#year=1999",
+			expected: "#year=1999",
+			line:     &songio.YearDirectiveLine{},
+		},
+		{
+			text:     "#year=nineties",
+			expected: "#year=nineties",
+			line:     &songio.UnknownDirectiveLine{},
+		},
+		{
+			text:     "#tags=worship,  slow ,",
+			expected: "#tags=worship, slow",
+			line:     &songio.TagsDirectiveLine{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			lines, err := songio.ReadAllLines(readSong(tc.text))
+			require.Nil(t, err)
+			require.Len(t, lines, 1)
+			require.IsType(t, tc.line, lines[0])
+
+			name, value, ok := songio.Directive(lines[0])
+			require.True(t, ok)
+			require.Equal(t, tc.expected, "#"+name+"="+value)
+
+			reread, err := songio.ReadAllLines(readSong(writeSong(t, songio.FromLines(lines))))
+			require.Nil(t, err)
+			require.Equal(t, lines, reread)
+		})
+	}
+}
+
+func TestParseTimeSignature(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected songio.TimeSignature
+		err      bool
+	}{
+		{text: "4/4", expected: songio.TimeSignature{Beats: 4, Value: 4}},
+		{text: "6 / 8", expected: songio.TimeSignature{Beats: 6, Value: 8}},
+		{text: "12/8", expected: songio.TimeSignature{Beats: 12, Value: 8}},
+		{text: "4", err: true},
+		{text: "0/4", err: true},
+		{text: "3/0", err: true},
+		{text: "7/6", err: true},
+		{text: "x/4", err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			actual, err := songio.ParseTimeSignature(tc.text)
+			if tc.err {
+				require.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected time.Duration
+		err      bool
+	}{
+		{text: "3:45", expected: 3*time.Minute + 45*time.Second},
+		{text: "0:05", expected: 5 * time.Second},
+		{text: "3 : 45", expected: 3*time.Minute + 45*time.Second},
+		{text: "3m45s", expected: 3*time.Minute + 45*time.Second},
+		{text: "3:5", err: true},
+		{text: "3:60", err: true},
+		{text: "-1:30", err: true},
+		{text: "0s", err: true},
+		{text: "long", err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			actual, err := songio.ParseDuration(tc.text)
+			if tc.err {
+				require.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.expected, actual)
+
+			reparsed, err := songio.ParseDuration(songio.FormatDuration(actual))
+			require.Nil(t, err)
+			require.Equal(t, actual, reparsed)
+		})
+	}
+}
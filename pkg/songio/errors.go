@@ -0,0 +1,32 @@
+package songio
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChordError is a word that could not be parsed as a chord on a line that is mostly chords.
+type ChordError struct {
+	Text  string
+	Range Range
+	Err   error
+}
+
+func (e *ChordError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %q is not a valid chord: %v", e.Range.Start.Line, e.Range.Start.Column, e.Text, e.Err)
+}
+
+func (e *ChordError) Unwrap() error {
+	return e.Err
+}
+
+type ChordErrors []*ChordError
+
+func (e ChordErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, ce := range e {
+		msgs = append(msgs, ce.Error())
+	}
+
+	return strings.Join(msgs, "\n")
+}
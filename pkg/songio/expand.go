@@ -0,0 +1,310 @@
+package songio
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Expand resolves the song into the order it is performed. When the song has an order directive, its sections are
+// played in that order, each with the directives that precede it, and the directive is removed. Otherwise, a section
+// without any content repeats the content of the earlier section with the same name.
+func Expand(src Reader) *SongExpander {
+	return &SongExpander{
+		src: src,
+	}
+}
+
+type SongExpander struct {
+	src   Reader
+	lines *Lines
+	err   error
+}
+
+func (s *SongExpander) Next() (Line, bool) {
+	if s.lines == nil {
+		s.lines = FromLines(s.expand())
+	}
+
+	return s.lines.Next()
+}
+
+func (s *SongExpander) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+
+	return s.src.Err()
+}
+
+// sectionBlock is a section of a song along with the lines that precede it, since the end of the previous section.
+type sectionBlock struct {
+	name    string
+	leading []Line
+	lines   []Line
+}
+
+func (b *sectionBlock) hasContent() bool {
+	return sectionHasContent(b.lines)
+}
+
+func (s *SongExpander) expand() []Line {
+	var (
+		prelude  []Line
+		blocks   []*sectionBlock
+		current  *sectionBlock
+		pending  []Line
+		order    *OrderDirectiveLine
+		inPrelog = true
+	)
+
+	for line, ok := s.src.Next(); ok; line, ok = s.src.Next() {
+		switch tl := line.(type) {
+		case *OrderDirectiveLine:
+			if order == nil {
+				order = tl
+			}
+			continue
+		case *SectionStartDirectiveLine:
+			inPrelog = false
+			current = &sectionBlock{name: tl.Name, leading: pending}
+			pending = nil
+			blocks = append(blocks, current)
+		}
+
+		switch {
+		case current != nil:
+			current.lines = append(current.lines, line)
+			if _, ok := line.(*SectionEndDirectiveLine); ok {
+				current = nil
+			}
+		case inPrelog:
+			prelude = append(prelude, line)
+		default:
+			pending = append(pending, line)
+		}
+	}
+
+	definitions := make(map[string]*sectionBlock)
+	var names []string
+	for _, b := range blocks {
+		key := strings.ToLower(b.name)
+		if _, ok := definitions[key]; !ok && b.hasContent() {
+			definitions[key] = b
+			names = append(names, b.name)
+		}
+	}
+
+	result := append([]Line{}, prelude...)
+	if order != nil {
+		for _, entry := range order.Order {
+			name, ok := ResolveSection(names, entry)
+			if !ok {
+				s.err = fmt.Errorf("order: no section matches %q", entry)
+				continue
+			}
+
+			b := definitions[strings.ToLower(name)]
+			for _, line := range b.leading {
+				if _, ok := line.(EmptyLine); !ok {
+					result = append(result, cloneLine(line))
+				}
+			}
+			for _, line := range b.lines {
+				result = append(result, cloneLine(line))
+			}
+		}
+
+		return append(result, pending...)
+	}
+
+	for _, b := range blocks {
+		result = append(result, b.leading...)
+
+		def, ok := definitions[strings.ToLower(b.name)]
+		if b.hasContent() || !ok {
+			result = append(result, b.lines...)
+			continue
+		}
+
+		// Repeat the content of the definition between this section's own start and end.
+		result = append(result, b.lines[0])
+		for _, line := range def.lines[1:] {
+			if _, ok := line.(*SectionEndDirectiveLine); ok {
+				break
+			}
+			result = append(result, cloneLine(line))
+		}
+		result = append(result, b.lines[1:]...)
+	}
+
+	return append(result, pending...)
+}
+
+// ResolveSection finds the section that the reference, from an order directive, refers to. The reference may be the
+// name of the section, ignoring case, its abbreviation, such as "V1" for "Verse 1" or "PC" for "Pre-Chorus", or the
+// start of exactly one section's name.
+func ResolveSection(names []string, ref string) (string, bool) {
+	for _, name := range names {
+		if strings.EqualFold(name, ref) {
+			return name, true
+		}
+	}
+
+	for _, name := range names {
+		if strings.EqualFold(abbreviateSection(name), ref) {
+			return name, true
+		}
+	}
+
+	var match string
+	for _, name := range names {
+		if len(ref) > 0 && strings.HasPrefix(strings.ToLower(name), strings.ToLower(ref)) {
+			if len(match) > 0 {
+				return "", false
+			}
+			match = name
+		}
+	}
+
+	return match, len(match) > 0
+}
+
+// abbreviateSection returns the first letter of each word in the name followed by any number in it.
+func abbreviateSection(name string) string {
+	var sb strings.Builder
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-'
+	})
+	for _, word := range words {
+		runes := []rune(word)
+		if unicode.IsDigit(runes[0]) {
+			sb.WriteString(word)
+			continue
+		}
+
+		sb.WriteRune(unicode.ToUpper(runes[0]))
+		sb.WriteString(strings.TrimLeftFunc(word, func(r rune) bool { return !unicode.IsDigit(r) }))
+	}
+
+	return sb.String()
+}
+
+// parseOrder splits the value of an order directive into its entries, which are separated by commas when there are
+// any, so that names may have spaces, or otherwise by spaces.
+func parseOrder(value string) []string {
+	var entries []string
+	if strings.ContainsRune(value, ',') {
+		for _, entry := range strings.Split(value, ",") {
+			if entry = strings.TrimSpace(entry); len(entry) > 0 {
+				entries = append(entries, entry)
+			}
+		}
+		return entries
+	}
+
+	return strings.Fields(value)
+}
+
+// formatOrder writes the entries of an order directive so that parseOrder reads them back, separating them with commas
+// when any of them has a space, or otherwise with spaces. A lone entry with a space is followed by a comma.
+func formatOrder(order []string) string {
+	for _, entry := range order {
+		if strings.IndexFunc(entry, unicode.IsSpace) >= 0 {
+			if len(order) == 1 {
+				return entry + ","
+			}
+			return strings.Join(order, ", ")
+		}
+	}
+
+	return strings.Join(order, " ")
+}
+
+func sectionHasContent(lines []Line) bool {
+	for _, line := range lines {
+		switch line.(type) {
+		case *ChordLine, *TextLine:
+			return true
+		}
+	}
+
+	return false
+}
+
+// cloneLine copies the line, so that a repeated line may be changed, such as when transposed, without changing the
+// original.
+func cloneLine(line Line) Line {
+	switch tl := line.(type) {
+	case *ChordLine:
+		c := *tl
+		c.Chords = make([]*ChordOffset, len(tl.Chords))
+		for i, co := range tl.Chords {
+			coc := *co
+			c.Chords[i] = &coc
+		}
+		c.Markers = make([]*MarkerOffset, len(tl.Markers))
+		for i, mo := range tl.Markers {
+			moc := *mo
+			c.Markers[i] = &moc
+		}
+		return &c
+	case *TextLine:
+		c := *tl
+		return &c
+	case *KeyDirectiveLine:
+		c := *tl
+		return &c
+	case *AlbumDirectiveLine:
+		c := *tl
+		return &c
+	case *ArtistDirectiveLine:
+		c := *tl
+		return &c
+	case *CapoDirectiveLine:
+		c := *tl
+		return &c
+	case *CCLIDirectiveLine:
+		c := *tl
+		return &c
+	case *CommentDirectiveLine:
+		c := *tl
+		return &c
+	case *CopyrightDirectiveLine:
+		c := *tl
+		return &c
+	case *DurationDirectiveLine:
+		c := *tl
+		return &c
+	case *TagsDirectiveLine:
+		c := *tl
+		c.Tags = append([]string(nil), tl.Tags...)
+		return &c
+	case *TempoDirectiveLine:
+		c := *tl
+		return &c
+	case *TimeDirectiveLine:
+		c := *tl
+		return &c
+	case *YearDirectiveLine:
+		c := *tl
+		return &c
+	case *OrderDirectiveLine:
+		c := *tl
+		return &c
+	case *SectionStartDirectiveLine:
+		c := *tl
+		return &c
+	case *SectionEndDirectiveLine:
+		c := *tl
+		return &c
+	case *TitleDirectiveLine:
+		c := *tl
+		return &c
+	case *UnknownDirectiveLine:
+		c := *tl
+		return &c
+	default:
+		return line
+	}
+}
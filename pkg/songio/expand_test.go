@@ -0,0 +1,102 @@
+package songio_test
+
+import (
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpand(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "repeats empty sections",
+			text:     "[Chorus]\nC\nla\n\n[Verse]\nG\nlo\n\n[Chorus]\n",
+			expected: "[Chorus]\nC\nla\n\n[Verse]\nG\nlo\n\n[Chorus]\nC\nla\n\n",
+		},
+		{
+			name:     "follows the order",
+			text:     "#order=V C V C\n[Verse]\nlo\n\n[Chorus]\nla\n",
+			expected: "[Verse]\nlo\n\n[Chorus]\nla\n\n[Verse]\nlo\n\n[Chorus]\nla\n\n",
+		},
+		{
+			name:     "order with names with spaces",
+			text:     "#order=Verse 1, Chorus, Verse 1\n[Verse 1]\nlo\n\n[Chorus]\nla\n",
+			expected: "[Verse 1]\nlo\n\n[Chorus]\nla\n\n[Verse 1]\nlo\n\n",
+		},
+		{
+			name:     "keeps the directives before the sections",
+			text:     "#title=Song\n#order=C C\n[Chorus]\nla\n",
+			expected: "#title=Song\n[Chorus]\nla\n\n[Chorus]\nla\n\n",
+		},
+		{
+			name:     "keeps the lines after the last section with the order",
+			text:     "#order=C C\n[Chorus]\nla\n\n\n#comment=fade out\n",
+			expected: "[Chorus]\nla\n\n[Chorus]\nla\n\n#comment=fade out\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, writeSong(t, songio.Expand(readSong(tc.text))))
+		})
+	}
+}
+
+func TestExpand_ClonesRepeatedDirectives(t *testing.T) {
+	lines, err := songio.ReadAllLines(songio.Expand(readSong("#order=C C\n[Chorus]\nla\n#capo=2\n#tags=live, slow\n")))
+	require.Nil(t, err)
+
+	var capos []*songio.CapoDirectiveLine
+	var tags []*songio.TagsDirectiveLine
+	for _, line := range lines {
+		switch tl := line.(type) {
+		case *songio.CapoDirectiveLine:
+			capos = append(capos, tl)
+		case *songio.TagsDirectiveLine:
+			tags = append(tags, tl)
+		}
+	}
+
+	require.Len(t, capos, 2)
+	require.Len(t, tags, 2)
+
+	capos[1].Fret = 4
+	tags[1].Tags[0] = "studio"
+	require.Equal(t, 2, capos[0].Fret)
+	require.Equal(t, []string{"live", "slow"}, tags[0].Tags)
+}
+
+func TestExpand_UnknownSection(t *testing.T) {
+	_, err := songio.ReadAllLines(songio.Expand(readSong("#order=Verse Bridge\n[Verse]\nla\n")))
+	require.NotNil(t, err)
+}
+
+func TestResolveSection(t *testing.T) {
+	names := []string{"Verse 1", "Verse 2", "Pre-Chorus", "Chorus", "Bridge"}
+
+	testCases := []struct {
+		ref      string
+		expected string
+		ok       bool
+	}{
+		{ref: "chorus", expected: "Chorus", ok: true},
+		{ref: "V2", expected: "Verse 2", ok: true},
+		{ref: "PC", expected: "Pre-Chorus", ok: true},
+		{ref: "Bri", expected: "Bridge", ok: true},
+		{ref: "Verse", ok: false},
+		{ref: "Outro", ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.ref, func(t *testing.T) {
+			actual, ok := songio.ResolveSection(names, tc.ref)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
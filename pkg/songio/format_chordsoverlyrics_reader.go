@@ -2,7 +2,9 @@ package songio
 
 import (
 	"bufio"
+	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -17,10 +19,20 @@ type ChordsOverLyricsReader struct {
 
 	blankLineCount     int
 	currentSectionName string
+	lineNumber         int
+	songHasTitle       bool
+
+	// lookahead holds the lines read ahead of the current one, which are read before the rest of the source.
+	lookahead []string
 
 	saveLine Line
 
-	err error
+	chordErrors ChordErrors
+	err         error
+
+	// Strict causes Err to report the words that could not be parsed as chords on lines that are mostly chords.
+	// Otherwise, those lines are silently read as lyrics.
+	Strict bool
 }
 
 func ReadChordsOverLyrics(keyParser key.Parser, chordParser chord.Parser, src io.Reader) *ChordsOverLyricsReader {
@@ -34,8 +46,9 @@ func ReadChordsOverLyrics(keyParser key.Parser, chordParser chord.Parser, src io
 func (r *ChordsOverLyricsReader) Next() (Line, bool) {
 	if r.saveLine != nil {
 		for r.blankLineCount > 0 {
+			pos := Position{Line: PositionOf(r.saveLine).Line - r.blankLineCount, Column: 1}
 			r.blankLineCount--
-			return EmptyLine{}, true
+			return EmptyLine{Pos: pos}, true
 		}
 
 		line := r.saveLine
@@ -48,9 +61,12 @@ func (r *ChordsOverLyricsReader) Next() (Line, bool) {
 		return line, true
 	}
 
-	if !r.scanner.Scan() {
+	text, ok := r.scan()
+	if !ok {
 		if len(r.currentSectionName) > 0 {
+			pos := Position{Line: r.lineNumber, Column: 1}
 			if r.blankLineCount > 0 {
+				pos.Line = r.lineNumber - r.blankLineCount + 1
 				r.blankLineCount--
 			}
 
@@ -58,18 +74,22 @@ func (r *ChordsOverLyricsReader) Next() (Line, bool) {
 			r.currentSectionName = ""
 			return &SectionEndDirectiveLine{
 				Name: currentSectionName,
+				Pos:  pos,
 			}, true
 		}
 
 		for r.blankLineCount > 0 {
+			pos := Position{Line: r.lineNumber - r.blankLineCount + 1, Column: 1}
 			r.blankLineCount--
-			return EmptyLine{}, true
+			return EmptyLine{Pos: pos}, true
 		}
 
 		return nil, false
 	}
 
-	line := r.parseLine(r.scanner.Text())
+	r.lineNumber++
+	pos := Position{Line: r.lineNumber, Column: 1}
+	line := r.parseLine(text, pos)
 
 	switch tl := line.(type) {
 	case EmptyLine:
@@ -84,6 +104,7 @@ func (r *ChordsOverLyricsReader) Next() (Line, bool) {
 			r.currentSectionName = ""
 			return &SectionEndDirectiveLine{
 				Name: currentSectionName,
+				Pos:  pos,
 			}, true
 		}
 	case *SectionStartDirectiveLine:
@@ -98,68 +119,166 @@ func (r *ChordsOverLyricsReader) Next() (Line, bool) {
 
 			return &SectionEndDirectiveLine{
 				Name: currentSectionName,
+				Pos:  pos,
 			}, true
 		} else {
 			r.currentSectionName = tl.Name
 		}
+	case *SongBreakLine:
+		if len(r.currentSectionName) > 0 {
+			r.saveLine = line
+			if r.blankLineCount > 0 {
+				r.blankLineCount--
+			}
+
+			currentSectionName := r.currentSectionName
+			r.currentSectionName = ""
+
+			return &SectionEndDirectiveLine{
+				Name: currentSectionName,
+				Pos:  pos,
+			}, true
+		}
 	}
 
 	return line, true
 }
 
+// ChordErrors lists the words that could not be parsed as chords on lines that are mostly chords, regardless of
+// whether the reader is strict.
+func (r *ChordsOverLyricsReader) ChordErrors() ChordErrors {
+	return r.chordErrors
+}
+
 func (r *ChordsOverLyricsReader) Err() error {
-	return r.scanner.Err()
+	if err := r.scanner.Err(); err != nil {
+		return fmt.Errorf("reading line %d: %w", r.lineNumber+1, err)
+	}
+
+	if r.Strict && len(r.chordErrors) > 0 {
+		return r.chordErrors
+	}
+
+	return nil
+}
+
+func (r *ChordsOverLyricsReader) isChord(word string) bool {
+	_, err := r.chordParser.ParseChord(word)
+	return err == nil
 }
 
-func (r *ChordsOverLyricsReader) parseContent(text string) Line {
+func (r *ChordsOverLyricsReader) parseContent(text string, pos Position) Line {
 	var chordSegments []*ChordOffset
+	var markers []*MarkerOffset
+	var misses []*ChordError
 
+	// Offsets are measured in runes so that chords line up with the lyrics when they contain non-ASCII symbols.
 	wordStartIdx := -1
-	for i, n := range text {
+	wordStartCol := -1
+	col := 0
+	for i, n := range text + " " {
 		if unicode.IsSpace(n) {
 			if wordStartIdx > -1 {
-				chord, err := r.chordParser.ParseChord(text[wordStartIdx:i])
-				if err != nil {
-					return &TextLine{
-						Text: text,
-					}
+				word := text[wordStartIdx:i]
+				rng := Range{
+					Start: Position{Line: pos.Line, Column: wordStartCol + 1, Offset: wordStartIdx},
+					End:   Position{Line: pos.Line, Column: col + 1, Offset: i},
+				}
+
+				// Parentheses may be written against the chords they group. Chords never start with one.
+				for len(word) > 1 && word[0] == '(' {
+					markers = append(markers, newGroupMarker(word[:1], wordStartCol, rng.Start))
+					word = word[1:]
+					wordStartCol++
+					rng.Start.Column++
+					rng.Start.Offset++
 				}
 
-				chordSegments = append(chordSegments, &ChordOffset{
-					Chord:  chord,
-					Offset: wordStartIdx,
-				})
+				// Chords may end with a parenthesis of their own, as in Cmaj9(#11), so a closing parenthesis is only
+				// split off when the word is not a chord with it.
+				var groupEnds []*MarkerOffset
+				for len(word) > 1 && word[len(word)-1] == ')' && !r.isChord(word) {
+					rng.End.Column--
+					rng.End.Offset--
+					groupEnds = append(groupEnds, newGroupMarker(word[len(word)-1:], rng.End.Column-1, rng.End))
+					word = word[:len(word)-1]
+				}
+				markers = append(markers, groupEnds...)
+
+				if marker, ok := ParseMarker(word); ok {
+					markers = append(markers, &MarkerOffset{
+						Marker: marker,
+						Offset: wordStartCol,
+						Range:  rng,
+					})
+				} else if chord, err := r.chordParser.ParseChord(word); err != nil {
+					misses = append(misses, &ChordError{
+						Text:  word,
+						Range: rng,
+						Err:   err,
+					})
+				} else {
+					chordSegments = append(chordSegments, &ChordOffset{
+						Chord:  chord,
+						Offset: wordStartCol,
+						Range:  rng,
+					})
+				}
 				wordStartIdx = -1
 			}
 		} else if wordStartIdx == -1 {
 			wordStartIdx = i
+			wordStartCol = col
 		}
+		col++
 	}
 
-	if wordStartIdx > -1 {
-		chord, err := r.chordParser.ParseChord(text[wordStartIdx:])
-		if err != nil {
-			return &TextLine{
-				Text: text,
+	if len(misses) > 0 {
+		// A line where most of the words are chords was probably meant to be a chord line. Parentheses are not counted,
+		// since lyrics use them too.
+		notation := len(chordSegments)
+		for _, mo := range markers {
+			if kind := mo.Marker.Kind; kind != MarkerKindGroupStart && kind != MarkerKindGroupEnd {
+				notation++
 			}
 		}
 
-		chordSegments = append(chordSegments, &ChordOffset{
-			Chord:  chord,
-			Offset: wordStartIdx,
-		})
+		if notation > len(misses) {
+			r.chordErrors = append(r.chordErrors, misses...)
+		}
+
+		return &TextLine{
+			Text: text,
+			Pos:  pos,
+		}
 	}
 
 	return &ChordLine{
-		Chords: chordSegments,
+		Chords:  chordSegments,
+		Markers: markers,
+		Pos:     pos,
+	}
+}
+
+func newGroupMarker(text string, offset int, start Position) *MarkerOffset {
+	marker, _ := ParseMarker(text)
+	end := start
+	end.Column++
+	end.Offset++
+
+	return &MarkerOffset{
+		Marker: marker,
+		Offset: offset,
+		Range:  Range{Start: start, End: end},
 	}
 }
 
-func (r *ChordsOverLyricsReader) parseDirective(text string) Line {
+func (r *ChordsOverLyricsReader) parseDirective(text string, pos Position) Line {
 	idx := strings.IndexRune(text, '=')
 	if idx < 0 {
 		return &UnknownDirectiveLine{
 			Name: text[1:],
+			Pos:  pos,
 		}
 	}
 
@@ -167,14 +286,60 @@ func (r *ChordsOverLyricsReader) parseDirective(text string) Line {
 	value := strings.TrimSpace(text[idx+1:])
 
 	switch name {
+	case "album":
+		return &AlbumDirectiveLine{Album: value, Pos: pos}
+	case "artist":
+		return &ArtistDirectiveLine{Artist: value, Pos: pos}
+	case "capo":
+		if fret, err := strconv.Atoi(value); err == nil && fret >= 0 {
+			return &CapoDirectiveLine{Fret: fret, Pos: pos}
+		}
+	case "ccli":
+		return &CCLIDirectiveLine{Number: value, Pos: pos}
+	case "comment":
+		return &CommentDirectiveLine{Comment: value, Pos: pos}
+	case "copyright":
+		return &CopyrightDirectiveLine{Copyright: value, Pos: pos}
+	case "duration":
+		if d, err := ParseDuration(value); err == nil {
+			return &DurationDirectiveLine{Duration: d, Pos: pos}
+		}
+	case "tags":
+		if tags := parseTags(value); len(tags) > 0 {
+			return &TagsDirectiveLine{Tags: tags, Pos: pos}
+		}
+	case "tempo":
+		// The unit is allowed, since it is commonly written.
+		bpm := strings.TrimSpace(strings.TrimSuffix(strings.ToLower(value), "bpm"))
+		if n, err := strconv.Atoi(bpm); err == nil && n > 0 {
+			return &TempoDirectiveLine{BPM: n, Pos: pos}
+		}
+	case "time":
+		if ts, err := ParseTimeSignature(value); err == nil {
+			return &TimeDirectiveLine{Time: ts, Pos: pos}
+		}
 	case "title":
+		r.songHasTitle = true
 		return &TitleDirectiveLine{
 			Title: value,
+			Pos:   pos,
+		}
+	case "year":
+		if year, err := strconv.Atoi(value); err == nil && year > 0 {
+			return &YearDirectiveLine{Year: year, Pos: pos}
 		}
 	case "key":
 		if key, err := r.keyParser.ParseKey(value); err == nil {
 			return &KeyDirectiveLine{
 				Key: key,
+				Pos: pos,
+			}
+		}
+	case "order", "arrangement":
+		if order := parseOrder(value); len(order) > 0 {
+			return &OrderDirectiveLine{
+				Order: order,
+				Pos:   pos,
 			}
 		}
 	}
@@ -182,31 +347,94 @@ func (r *ChordsOverLyricsReader) parseDirective(text string) Line {
 	return &UnknownDirectiveLine{
 		Name:  name,
 		Value: value,
+		Pos:   pos,
 	}
 }
 
-func (r *ChordsOverLyricsReader) parseLine(text string) Line {
+// isSongBreak indicates whether the text is a line of at least three dashes, which separates the songs in a songbook
+// when it is between two songs with titles. Otherwise, it is read as lyrics.
+func (r *ChordsOverLyricsReader) isSongBreak(text string) bool {
+	text = strings.TrimSpace(text)
+	if len(text) < 3 || strings.Trim(text, "-") != "" || !r.songHasTitle {
+		return false
+	}
+
+	// The next song's title may follow other directives and blank lines, but not its content.
+	for i := 0; ; i++ {
+		if i == len(r.lookahead) {
+			if !r.scanner.Scan() {
+				return false
+			}
+			r.lookahead = append(r.lookahead, r.scanner.Text())
+		}
+
+		next := strings.TrimSpace(r.lookahead[i])
+		switch {
+		case len(next) == 0:
+			continue
+		case strings.HasPrefix(next, "#title="):
+			return true
+		case !strings.HasPrefix(next, "#"):
+			return false
+		}
+	}
+}
+
+// scan returns the next line of the source, starting with any that were read ahead.
+func (r *ChordsOverLyricsReader) scan() (string, bool) {
+	if len(r.lookahead) > 0 {
+		text := r.lookahead[0]
+		r.lookahead = r.lookahead[1:]
+		return text, true
+	}
+
+	if !r.scanner.Scan() {
+		return "", false
+	}
+
+	return r.scanner.Text(), true
+}
+
+// parseTags splits the value of a tags directive on commas.
+func parseTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); len(tag) > 0 {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+func (r *ChordsOverLyricsReader) parseLine(text string, pos Position) Line {
 	if isEmptyOrWhitespace(text) {
-		return EmptyLine{}
+		return EmptyLine{Pos: pos}
+	}
+
+	if r.isSongBreak(text) {
+		r.songHasTitle = false
+		return &SongBreakLine{Pos: pos}
 	}
 
 	switch text[0] {
 	case '#':
-		return r.parseDirective(text)
+		return r.parseDirective(text, pos)
 	case '[':
-		return r.parseSectionStart(text)
+		return r.parseSectionStart(text, pos)
 	default:
-		return r.parseContent(text)
+		return r.parseContent(text, pos)
 	}
 }
 
-func (r *ChordsOverLyricsReader) parseSectionStart(text string) Line {
+func (r *ChordsOverLyricsReader) parseSectionStart(text string, pos Position) Line {
 	idx := strings.IndexRune(text, ']')
 	if idx < 0 || !isEmptyOrWhitespace(text[idx+1:]) {
-		return r.parseContent(text)
+		return r.parseContent(text, pos)
 	}
 
 	return &SectionStartDirectiveLine{
 		Name: text[1:idx],
+		Pos:  pos,
 	}
 }
@@ -0,0 +1,155 @@
+package songio_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadChordsOverLyrics_ChordLine(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected []string
+	}{
+		{
+			text:     "C   G/B   Am7",
+			expected: []string{"C@0", "G/B@4", "Am7@10"},
+		},
+		{
+			text:     "Cmaj9(#11)  Am7(b5)  E7(#9)",
+			expected: []string{"Cmaj9(#11)@0", "Am7(b5)@12", "E7(#9)@21"},
+		},
+		{
+			text:     "| Cmaj9(#11) G/B | (x2)",
+			expected: []string{"|@0", "Cmaj9(#11)@2", "G/B@13", "|@17", "(@19", "x2@20", ")@22"},
+		},
+		{
+			text:     "(Dsus Am7(b5)) E7(#9)",
+			expected: []string{"(@0", "Dsus@1", "Am7(b5)@6", ")@13", "E7(#9)@15"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			th := theory.Default()
+			lines, err := songio.ReadAllLines(songio.ReadChordsOverLyrics(th, th, strings.NewReader(tc.text)))
+			require.Nil(t, err)
+			require.Len(t, lines, 1)
+
+			cl, ok := lines[0].(*songio.ChordLine)
+			require.True(t, ok, "expected a chord line, but got %T", lines[0])
+
+			var actual []string
+			for _, seg := range cl.Segments() {
+				actual = append(actual, fmt.Sprintf("%s@%d", seg.Text, seg.Offset))
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestReadChordsOverLyrics_Lyrics(t *testing.T) {
+	th := theory.Default()
+	lines, err := songio.ReadAllLines(songio.ReadChordsOverLyrics(th, th, strings.NewReader("(I know) you were there")))
+	require.Nil(t, err)
+	require.Len(t, lines, 1)
+	require.IsType(t, &songio.TextLine{}, lines[0])
+}
+
+func TestReadChordsOverLyrics_Strict(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected []string
+	}{
+		{
+			text: "G   D   Am   C",
+		},
+		{
+			text:     "G   D   Hm   C",
+			expected: []string{"Hm@1:9"},
+		},
+		{
+			text:     "| G  Dsus  Q7 | (x2)",
+			expected: []string{"Q7@1:12"},
+		},
+		{
+			text: "G is for the way you look at me",
+		},
+		{
+			text: "Am I the one",
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, strict := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s strict=%t", tc.text, strict), func(t *testing.T) {
+				th := theory.Default()
+				src := songio.ReadChordsOverLyrics(th, th, strings.NewReader(tc.text))
+				src.Strict = strict
+
+				lines, err := songio.ReadAllLines(src)
+				if strict && len(tc.expected) > 0 {
+					require.Equal(t, src.ChordErrors(), err)
+				} else {
+					require.Nil(t, err)
+				}
+				require.Len(t, lines, 1)
+
+				var actual []string
+				for _, ce := range src.ChordErrors() {
+					actual = append(actual, fmt.Sprintf("%s@%d:%d", ce.Text, ce.Range.Start.Line, ce.Range.Start.Column))
+				}
+				require.Equal(t, tc.expected, actual)
+
+				if len(tc.expected) > 0 {
+					require.IsType(t, &songio.TextLine{}, lines[0])
+				}
+			})
+		}
+	}
+}
+
+func TestReadChordsOverLyrics_SongBreak(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "between songs with titles",
+			text:     "#title=One\nla\n---\n#title=Two\nlo\n",
+			expected: []string{"*songio.TitleDirectiveLine", "*songio.TextLine", "*songio.SongBreakLine", "*songio.TitleDirectiveLine", "*songio.TextLine"},
+		},
+		{
+			name:     "without a title after",
+			text:     "#title=One\nla\n---\nlo\n",
+			expected: []string{"*songio.TitleDirectiveLine", "*songio.TextLine", "*songio.TextLine", "*songio.TextLine"},
+		},
+		{
+			name:     "without a title before",
+			text:     "la\n---\n#title=Two\nlo\n",
+			expected: []string{"*songio.TextLine", "*songio.TextLine", "*songio.TitleDirectiveLine", "*songio.TextLine"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lines, err := songio.ReadAllLines(readSong(tc.text))
+			require.Nil(t, err)
+
+			var actual []string
+			for _, line := range lines {
+				actual = append(actual, fmt.Sprintf("%T", line))
+			}
+			require.Equal(t, tc.expected, actual)
+
+			for i, line := range lines {
+				require.Equal(t, i+1, songio.PositionOf(line).Line)
+			}
+		})
+	}
+}
@@ -19,33 +19,22 @@ func WriteChordsOverLyrics(noteNamer note.Namer, src Reader, w io.Writer) (int,
 			sb.WriteString("[")
 			sb.WriteString(tl.Name)
 			sb.WriteString("]")
-		case *KeyDirectiveLine:
-			sb.WriteString("#key=")
-			sb.WriteString(tl.Key.Name)
-		case *TitleDirectiveLine:
-			sb.WriteString("#title=")
-			sb.WriteString(tl.Title)
-		case *UnknownDirectiveLine:
-			sb.WriteString("#")
-			sb.WriteString(tl.Name)
-			if len(tl.Value) > 0 {
-				sb.WriteString("=")
-				sb.WriteString(tl.Value)
-			}
+		case *SongBreakLine:
+			sb.WriteString("---")
 		case *TextLine:
 			sb.WriteString(tl.Text)
 		case *ChordLine:
-			currentOffset := 0
-			for _, chordOffset := range tl.Chords {
-				offsetDiff := chordOffset.Offset - currentOffset
-				if offsetDiff > 0 {
-					sb.WriteString(strings.Repeat(" ", offsetDiff))
-					currentOffset += offsetDiff
+			sb.WriteString(RenderChordLine(tl, func(seg Segment) string {
+				return seg.Text
+			}))
+		default:
+			if name, value, ok := Directive(line); ok {
+				sb.WriteString("#")
+				sb.WriteString(name)
+				if len(value) > 0 {
+					sb.WriteString("=")
+					sb.WriteString(value)
 				}
-
-				chordName := chordOffset.Chord.Name
-				sb.WriteString(chordName)
-				currentOffset += len(chordName)
 			}
 		}
 
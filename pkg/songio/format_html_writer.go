@@ -0,0 +1,205 @@
+package songio
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/craiggwilson/songtool/pkg/theory/note"
+)
+
+// HTMLStylesheet lays out the HTML written by WriteHTML. It sets no colors, so that they can be added separately.
+const HTMLStylesheet = `.song { font-family: sans-serif; line-height: 1.3; }
+.song .title { margin: 0 0 0.25em; }
+.song .directives { display: grid; grid-template-columns: max-content auto; gap: 0 1em; margin: 0 0 1em; }
+.song .directives dt { font-weight: bold; }
+.song .directives dd { margin: 0; }
+.song .comment { font-style: italic; margin: 0 0 0.5em; }
+.song .section { margin: 0 0 1em; break-inside: avoid; }
+.song .section-name { font-size: 1em; margin: 0 0 0.25em; }
+.song .line { display: flex; flex-wrap: wrap; align-items: flex-end; }
+.song .chunk { display: inline-flex; flex-direction: column; }
+.song .chords { white-space: pre; padding-right: 0.5em; }
+.song .chords:empty::before { content: "\00a0"; }
+.song .lyrics { white-space: pre; }
+.song .chord-line { white-space: pre; }
+.song .empty { height: 1em; }
+.song .song-break { margin: 2em 0; }
+@media (max-width: 600px) { .song { font-size: 0.9em; } }
+`
+
+// WriteHTML writes the song as an HTML fragment to be styled with HTMLStylesheet. A chord line followed by lyrics is
+// split into chunks at each chord, with the chord above the lyrics it is played over, so that the chords stay with
+// their lyrics when a narrow screen wraps the line. Sections are written as blocks and the other directives as a list
+// of details.
+func WriteHTML(noteNamer note.Namer, src Reader, w io.Writer) (int, error) {
+	hw := htmlWriter{w: w}
+	hw.write(`<div class="song">` + "\n")
+
+	var pending Line
+	i := 0
+	for {
+		line, ok := pending, pending != nil
+		pending = nil
+		if !ok {
+			if line, ok = src.Next(); !ok {
+				break
+			}
+		}
+
+		switch tl := line.(type) {
+		case EmptyLine:
+			hw.pendingBlankLines++
+		case *TitleDirectiveLine:
+			hw.element(fmt.Sprintf(`<h1 class="title">%s</h1>`, html.EscapeString(tl.Title)))
+		case *CommentDirectiveLine:
+			hw.element(fmt.Sprintf(`<p class="comment">%s</p>`, html.EscapeString(tl.Comment)))
+		case *SectionStartDirectiveLine:
+			hw.closeSection()
+			hw.element(fmt.Sprintf(`<section class="section"><h2 class="section-name">%s</h2>`, html.EscapeString(tl.Name)))
+			hw.inSection = true
+		case *SectionEndDirectiveLine:
+			hw.closeSection()
+		case *SongBreakLine:
+			hw.closeSection()
+			hw.element(`<hr class="song-break">`)
+		case *TextLine:
+			hw.element(`<div class="line"><span class="lyrics">` + html.EscapeString(tl.Text) + `</span></div>`)
+		case *ChordLine:
+			next, ok := src.Next()
+			if text, isText := next.(*TextLine); ok && isText {
+				hw.element(renderHTMLChunks(tl, text.Text))
+				i++
+			} else {
+				pending = next
+				hw.element(`<div class="chord-line">` + RenderChordLine(tl, renderHTMLSegment) + `</div>`)
+			}
+		default:
+			if name, value, ok := Directive(line); ok {
+				hw.directives = append(hw.directives, [2]string{name, value})
+			}
+		}
+
+		if hw.err != nil {
+			return hw.n, fmt.Errorf("writing line %d: %w", i, hw.err)
+		}
+
+		i++
+	}
+
+	hw.closeSection()
+	hw.write("</div>\n")
+	return hw.n, hw.err
+}
+
+type htmlWriter struct {
+	w   io.Writer
+	n   int
+	err error
+
+	// Blank lines and directives are held back until more content is written, so that blank lines don't end the song
+	// and directives next to each other are listed together.
+	pendingBlankLines int
+	directives        [][2]string
+	inSection         bool
+}
+
+func (hw *htmlWriter) write(s string) {
+	if hw.err != nil {
+		return
+	}
+
+	n, err := io.WriteString(hw.w, s)
+	hw.n += n
+	hw.err = err
+}
+
+// element writes the element on its own line, after the pending directives and blank lines.
+func (hw *htmlWriter) element(s string) {
+	hw.flushDirectives()
+	hw.write(strings.Repeat(`<div class="empty"></div>`+"\n", hw.pendingBlankLines))
+	hw.pendingBlankLines = 0
+	hw.write(s + "\n")
+}
+
+func (hw *htmlWriter) flushDirectives() {
+	if len(hw.directives) == 0 {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<dl class="directives">`)
+	for _, d := range hw.directives {
+		fmt.Fprintf(&sb, "<dt>%s</dt><dd>%s</dd>", html.EscapeString(d[0]), html.EscapeString(d[1]))
+	}
+	sb.WriteString("</dl>\n")
+
+	hw.directives = nil
+	hw.write(sb.String())
+}
+
+// closeSection ends the open section, dropping the blank lines at its end.
+func (hw *htmlWriter) closeSection() {
+	hw.flushDirectives()
+	hw.pendingBlankLines = 0
+	if hw.inSection {
+		hw.write("</section>\n")
+		hw.inSection = false
+	}
+}
+
+// renderHTMLChunks splits the lyrics at the offset of each chord and marker, pairing each of them with the lyrics that
+// follow it up to the next one. Chords and markers at the same offset share a chunk.
+func renderHTMLChunks(cl *ChordLine, lyrics string) string {
+	runes := []rune(lyrics)
+	lyricsAt := func(start, end int) string {
+		if start > len(runes) {
+			start = len(runes)
+		}
+		if end > len(runes) || end < 0 {
+			end = len(runes)
+		}
+		return string(runes[start:end])
+	}
+
+	var sb strings.Builder
+	chunk := func(chords, text string) {
+		fmt.Fprintf(&sb, `<span class="chunk"><span class="chords">%s</span><span class="lyrics">%s</span></span>`, chords, html.EscapeString(text))
+	}
+
+	sb.WriteString(`<div class="line">`)
+
+	segments := cl.Segments()
+	if len(segments) > 0 && segments[0].Offset > 0 {
+		chunk("", lyricsAt(0, segments[0].Offset))
+	}
+
+	for i := 0; i < len(segments); {
+		j := i
+		var chords []string
+		for ; j < len(segments) && segments[j].Offset == segments[i].Offset; j++ {
+			chords = append(chords, renderHTMLSegment(segments[j]))
+		}
+
+		end := -1
+		if j < len(segments) {
+			end = segments[j].Offset
+		}
+
+		chunk(strings.Join(chords, " "), lyricsAt(segments[i].Offset, end))
+		i = j
+	}
+
+	sb.WriteString(`</div>`)
+	return sb.String()
+}
+
+func renderHTMLSegment(seg Segment) string {
+	class := "chord"
+	if seg.Marker != nil {
+		class = "marker"
+	}
+
+	return fmt.Sprintf(`<span class="%s">%s</span>`, class, html.EscapeString(seg.Text))
+}
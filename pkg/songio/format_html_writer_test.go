@@ -0,0 +1,88 @@
+package songio_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteHTML(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name: "chords above their lyrics",
+			text: "G    D\nAmazing grace\n",
+			expected: []string{
+				`<div class="line">` +
+					`<span class="chunk"><span class="chords"><span class="chord">G</span></span><span class="lyrics">Amazi</span></span>` +
+					`<span class="chunk"><span class="chords"><span class="chord">D</span></span><span class="lyrics">ng grace</span></span>` +
+					`</div>`,
+			},
+		},
+		{
+			name: "lyrics before the first chord",
+			text: "   C\nOh la\n",
+			expected: []string{
+				`<div class="line">` +
+					`<span class="chunk"><span class="chords"></span><span class="lyrics">Oh </span></span>` +
+					`<span class="chunk"><span class="chords"><span class="chord">C</span></span><span class="lyrics">la</span></span>` +
+					`</div>`,
+			},
+		},
+		{
+			name:     "chords without lyrics",
+			text:     "G D\n\nla\n",
+			expected: []string{`<div class="chord-line"><span class="chord">G</span> <span class="chord">D</span></div>`},
+		},
+		{
+			name: "sections",
+			text: "[Verse 1]\nla\n",
+			expected: []string{
+				`<section class="section"><h2 class="section-name">Verse 1</h2>` + "\n" +
+					`<div class="line"><span class="lyrics">la</span></div>` + "\n" +
+					`</section>`,
+			},
+		},
+		{
+			name: "directives",
+			text: "#title=Song\n#artist=Someone\n#key=G\nla\n",
+			expected: []string{
+				`<h1 class="title">Song</h1>`,
+				`<dl class="directives"><dt>artist</dt><dd>Someone</dd><dt>key</dt><dd>G</dd></dl>`,
+			},
+		},
+		{
+			name:     "escapes text",
+			text:     "#title=Rock & Roll\n<la>\n",
+			expected: []string{`<h1 class="title">Rock &amp; Roll</h1>`, `<span class="lyrics">&lt;la&gt;</span>`},
+		},
+		{
+			name:     "song breaks",
+			text:     "#title=One\nla\n---\n#title=Two\nlo\n",
+			expected: []string{`<hr class="song-break">`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var sb strings.Builder
+			src := readSong(tc.text)
+			_, err := songio.WriteHTML(theory.Default(), src, &sb)
+			require.Nil(t, err)
+			require.Nil(t, src.Err())
+
+			actual := sb.String()
+			require.True(t, strings.HasPrefix(actual, `<div class="song">`+"\n"))
+			require.True(t, strings.HasSuffix(actual, "</div>\n"))
+			for _, expected := range tc.expected {
+				require.Contains(t, actual, expected)
+			}
+		})
+	}
+}
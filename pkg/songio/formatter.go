@@ -0,0 +1,193 @@
+package songio
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Format rewrites the song canonically. Directives are moved to the top, except key directives that follow the
+// start of the song's content, section names and text are trimmed, runs of blank lines are collapsed, and chords
+// are moved apart when they would otherwise overlap. Each song in a songbook is formatted on its own, the songs are
+// separated by song breaks, and the songbook doesn't end with blank lines.
+func Format(src Reader) *SongFormatter {
+	return &SongFormatter{
+		src: src,
+	}
+}
+
+type SongFormatter struct {
+	src   Reader
+	lines *Lines
+}
+
+func (s *SongFormatter) Next() (Line, bool) {
+	if s.lines == nil {
+		s.lines = FromLines(s.format())
+	}
+
+	return s.lines.Next()
+}
+
+func (s *SongFormatter) Err() error {
+	return s.src.Err()
+}
+
+func (s *SongFormatter) format() []Line {
+	var lines []Line
+	songs := ReadSongs(s.src)
+	for song, ok := songs.Next(); ok; song, ok = songs.Next() {
+		if len(lines) > 0 {
+			if _, ok := lines[len(lines)-1].(*SectionEndDirectiveLine); !ok {
+				lines = append(lines, EmptyLine{})
+			}
+			lines = append(lines, &SongBreakLine{})
+		}
+
+		lines = append(lines, formatSong(song)...)
+	}
+
+	// The blank lines that section ends are written as are dropped from the end of the songbook, where the sections
+	// end anyway.
+	end := len(lines)
+	for ; end > 0; end-- {
+		switch lines[end-1].(type) {
+		case EmptyLine, *SectionEndDirectiveLine:
+			continue
+		}
+		break
+	}
+
+	return lines[:end]
+}
+
+func formatSong(src Reader) []Line {
+	var titles, details, keys, orders, others, body []Line
+	hasContent := false
+	for line, ok := src.Next(); ok; line, ok = src.Next() {
+		switch tl := line.(type) {
+		case *TitleDirectiveLine:
+			tl.Title = strings.TrimSpace(tl.Title)
+			titles = append(titles, tl)
+		case *KeyDirectiveLine:
+			if hasContent {
+				body = append(body, tl)
+			} else {
+				keys = append(keys, tl)
+			}
+		case *ArtistDirectiveLine, *AlbumDirectiveLine, *YearDirectiveLine, *CCLIDirectiveLine, *CopyrightDirectiveLine,
+			*TagsDirectiveLine, *CapoDirectiveLine, *TempoDirectiveLine, *TimeDirectiveLine, *DurationDirectiveLine:
+			details = append(details, tl)
+		case *OrderDirectiveLine:
+			orders = append(orders, tl)
+		case *CommentDirectiveLine:
+			// Comments after the content are about the part of the song they are in.
+			if hasContent {
+				body = append(body, tl)
+			} else {
+				others = append(others, tl)
+			}
+		case *UnknownDirectiveLine:
+			tl.Name = strings.TrimSpace(tl.Name)
+			tl.Value = strings.TrimSpace(tl.Value)
+			others = append(others, tl)
+		case *SectionStartDirectiveLine:
+			tl.Name = formatSectionName(tl.Name)
+			hasContent = true
+			body = append(body, tl)
+		case *SectionEndDirectiveLine:
+			tl.Name = formatSectionName(tl.Name)
+			body = append(body, tl)
+		case *TextLine:
+			tl.Text = strings.TrimRightFunc(tl.Text, unicode.IsSpace)
+			hasContent = true
+			body = append(body, tl)
+		case *ChordLine:
+			formatChordOffsets(tl)
+			hasContent = true
+			body = append(body, tl)
+		default:
+			body = append(body, line)
+		}
+	}
+
+	lines := make([]Line, 0, len(titles)+len(details)+len(keys)+len(orders)+len(others)+len(body)+1)
+	lines = append(lines, titles...)
+	lines = append(lines, details...)
+	lines = append(lines, keys...)
+	lines = append(lines, orders...)
+	lines = append(lines, others...)
+
+	var blanks, keyChanges []Line
+	hasBody := false
+	for _, line := range body {
+		switch line.(type) {
+		case EmptyLine, *SectionEndDirectiveLine:
+			blanks = append(blanks, line)
+			continue
+		case *KeyDirectiveLine:
+			// A key change introduces the content after it, so it stays after the blank lines before that content.
+			keyChanges = append(keyChanges, line)
+			continue
+		case *SectionStartDirectiveLine:
+			if len(blanks) == 0 && len(lines) > 0 {
+				blanks = append(blanks, EmptyLine{})
+			}
+		}
+
+		if len(lines) > 0 && !hasBody && len(blanks) == 0 {
+			blanks = append(blanks, EmptyLine{})
+		}
+
+		lines = append(lines, collapseBlankLines(blanks, len(lines) > 0)...)
+		lines = append(lines, keyChanges...)
+		lines = append(lines, line)
+		blanks = blanks[:0]
+		keyChanges = keyChanges[:0]
+		hasBody = true
+	}
+
+	lines = append(lines, keyChanges...)
+	return append(lines, collapseBlankLines(blanks, false)...)
+}
+
+// collapseBlankLines reduces a run of blank lines to section ends, which are rendered as blank lines, or to a single
+// empty line when there are no section ends. When separate is false, only the section ends are kept.
+func collapseBlankLines(blanks []Line, separate bool) []Line {
+	var result []Line
+	for _, line := range blanks {
+		if _, ok := line.(*SectionEndDirectiveLine); ok {
+			result = append(result, line)
+		}
+	}
+
+	if len(result) == 0 && len(blanks) > 0 && separate {
+		result = append(result, EmptyLine{})
+	}
+
+	return result
+}
+
+func formatChordOffsets(cl *ChordLine) {
+	end := -1
+	var prev *Segment
+	for _, seg := range cl.Segments() {
+		gap := 1
+		if prev != nil && (prev.isMarker(MarkerKindGroupStart) || seg.isMarker(MarkerKindGroupEnd)) {
+			gap = 0
+		}
+
+		if seg.Offset < end+gap {
+			seg.Offset = end + gap
+			seg.setOffset(seg.Offset)
+		}
+
+		end = seg.Offset + utf8.RuneCountInString(seg.Text)
+		seg := seg
+		prev = &seg
+	}
+}
+
+func formatSectionName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
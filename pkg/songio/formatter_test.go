@@ -0,0 +1,53 @@
+package songio_test
+
+import (
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "moves directives to the top",
+			text:     "#key=G\n[Verse]\nG\nla\n#title=Song\n#artist=Someone\n",
+			expected: "#title=Song\n#artist=Someone\n#key=G\n\n[Verse]\nG\nla\n",
+		},
+		{
+			name:     "keeps key changes in place",
+			text:     "#key=G\n[Verse]\nla\n\n[Chorus]\n#key=A\nlo\n",
+			expected: "#key=G\n\n[Verse]\nla\n\n[Chorus]\n#key=A\nlo\n",
+		},
+		{
+			name:     "keeps key changes with the section they start",
+			text:     "#key=G\n[Chorus]\nla\n\n#key=A\n[Verse 2]\nlo\n",
+			expected: "#key=G\n\n[Chorus]\nla\n\n#key=A\n[Verse 2]\nlo\n",
+		},
+		{
+			name:     "collapses blank lines",
+			text:     "[Verse]\nla\n\n\n\n[Chorus]\nlo\n\n\n",
+			expected: "[Verse]\nla\n\n[Chorus]\nlo\n",
+		},
+		{
+			name:     "trims section names and text",
+			text:     "[  Verse   1 ]\nla   \n",
+			expected: "[Verse 1]\nla\n",
+		},
+		{
+			name:     "formats each song in a songbook",
+			text:     "#title=One\n[Verse]\nla\n\n\n---\n#key=C\n#title=Two\n[Chorus]\nlo\n",
+			expected: "#title=One\n\n[Verse]\nla\n\n---\n#title=Two\n#key=C\n\n[Chorus]\nlo\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, writeSong(t, songio.Format(readSong(tc.text))))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package songio
+
+import "encoding/json"
+
+// WithPositions wraps the lines so that their positions, and the ranges of their chords, are included when they are
+// marshaled as JSON.
+func WithPositions(lines []Line) []PositionedLine {
+	result := make([]PositionedLine, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, PositionedLine{line})
+	}
+
+	return result
+}
+
+// PositionedLine is a Line that includes its position when marshaled as JSON, as an object with the line and its
+// position.
+type PositionedLine struct {
+	Line Line
+}
+
+func (l PositionedLine) MarshalJSON() ([]byte, error) {
+	var line interface{} = l.Line
+	if cl, ok := l.Line.(*ChordLine); ok {
+		type positionedChordOffset struct {
+			*ChordOffset
+			Range Range `json:"range"`
+		}
+
+		chords := make([]positionedChordOffset, 0, len(cl.Chords))
+		for _, co := range cl.Chords {
+			chords = append(chords, positionedChordOffset{co, co.Range})
+		}
+
+		type positionedMarkerOffset struct {
+			*MarkerOffset
+			Range Range `json:"range"`
+		}
+
+		var markers []positionedMarkerOffset
+		for _, mo := range cl.Markers {
+			markers = append(markers, positionedMarkerOffset{mo, mo.Range})
+		}
+
+		line = struct {
+			Chords  []positionedChordOffset  `json:"chords"`
+			Markers []positionedMarkerOffset `json:"markers,omitempty"`
+		}{chords, markers}
+	}
+
+	return json.Marshal(struct {
+		Line     interface{} `json:"line"`
+		Position Position    `json:"position"`
+	}{line, PositionOf(l.Line)})
+}
@@ -0,0 +1,49 @@
+package songio_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPositions(t *testing.T) {
+	lines, err := songio.ReadAllLines(readSong("#title=Song\n[Verse]\nG   D\nla la\n\n---\n#title=Two\nlo\n"))
+	require.Nil(t, err)
+
+	data, err := json.Marshal(songio.WithPositions(lines))
+	require.Nil(t, err)
+
+	var actual []struct {
+		Line     json.RawMessage `json:"line"`
+		Position songio.Position `json:"position"`
+	}
+	require.Nil(t, json.Unmarshal(data, &actual))
+	require.Len(t, actual, len(lines))
+
+	for i, line := range lines {
+		require.Equal(t, songio.PositionOf(line), actual[i].Position)
+
+		cl, ok := line.(*songio.ChordLine)
+		if !ok {
+			expected, err := json.Marshal(line)
+			require.Nil(t, err)
+			require.JSONEq(t, string(expected), string(actual[i].Line))
+			continue
+		}
+
+		var chordLine struct {
+			Chords []struct {
+				Offset int          `json:"offset"`
+				Range  songio.Range `json:"range"`
+			} `json:"chords"`
+		}
+		require.Nil(t, json.Unmarshal(actual[i].Line, &chordLine))
+		require.Len(t, chordLine.Chords, len(cl.Chords))
+		for j, co := range cl.Chords {
+			require.Equal(t, co.Offset, chordLine.Chords[j].Offset)
+			require.Equal(t, co.Range, chordLine.Chords[j].Range)
+		}
+	}
+}
@@ -1,28 +1,194 @@
 package songio
 
-import "github.com/craiggwilson/songtool/pkg/theory/chord"
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+)
 
 type Line interface {
 	line()
 }
 
-type EmptyLine struct{}
+// Position is a location in the source of a song. Lines and columns start at 1, where columns count runes, and Offset
+// is the zero-based byte offset within the line. A zero Position means the location is unknown.
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	Offset int `json:"offset"`
+}
+
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// PositionOf returns the position of the line in the source of the song.
+func PositionOf(line Line) Position {
+	switch tl := line.(type) {
+	case EmptyLine:
+		return tl.Pos
+	case *ChordLine:
+		return tl.Pos
+	case *TextLine:
+		return tl.Pos
+	case *AlbumDirectiveLine:
+		return tl.Pos
+	case *ArtistDirectiveLine:
+		return tl.Pos
+	case *CapoDirectiveLine:
+		return tl.Pos
+	case *CCLIDirectiveLine:
+		return tl.Pos
+	case *CommentDirectiveLine:
+		return tl.Pos
+	case *CopyrightDirectiveLine:
+		return tl.Pos
+	case *DurationDirectiveLine:
+		return tl.Pos
+	case *KeyDirectiveLine:
+		return tl.Pos
+	case *OrderDirectiveLine:
+		return tl.Pos
+	case *SectionEndDirectiveLine:
+		return tl.Pos
+	case *SectionStartDirectiveLine:
+		return tl.Pos
+	case *SongBreakLine:
+		return tl.Pos
+	case *TagsDirectiveLine:
+		return tl.Pos
+	case *TempoDirectiveLine:
+		return tl.Pos
+	case *TimeDirectiveLine:
+		return tl.Pos
+	case *TitleDirectiveLine:
+		return tl.Pos
+	case *UnknownDirectiveLine:
+		return tl.Pos
+	case *YearDirectiveLine:
+		return tl.Pos
+	default:
+		return Position{}
+	}
+}
+
+type EmptyLine struct {
+	Pos Position `json:"-"`
+}
 
 func (EmptyLine) line() {}
 
+// SongBreakLine separates the songs in a songbook, a file with more than one song. It is written as "---", and is only
+// read as one between two songs with titles.
+type SongBreakLine struct {
+	Pos Position `json:"-"`
+}
+
+func (l *SongBreakLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal(UnknownDirectiveLine{
+		Name: "songBreak",
+	})
+}
+
+func (l *SongBreakLine) line() {}
+
 type ChordLine struct {
 	Chords []*ChordOffset `json:"chords"`
+	// Markers are the bar lines, repeat signs, and other notation between the chords.
+	Markers []*MarkerOffset `json:"markers,omitempty"`
+
+	Pos Position `json:"-"`
 }
 
 func (l *ChordLine) line() {}
 
+// Segments returns the chords and markers in the line, ordered by their offsets.
+func (l *ChordLine) Segments() []Segment {
+	segments := make([]Segment, 0, len(l.Chords)+len(l.Markers))
+	for _, co := range l.Chords {
+		segments = append(segments, Segment{Offset: co.Offset, Text: co.Chord.Name, Chord: co})
+	}
+	for _, mo := range l.Markers {
+		segments = append(segments, Segment{Offset: mo.Offset, Text: mo.Marker.Text, Marker: mo})
+	}
+
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].Offset < segments[j].Offset
+	})
+
+	return segments
+}
+
 type ChordOffset struct {
 	Chord  chord.Named `json:"chord"`
 	Offset int         `json:"offset"`
+
+	// Range is the location of the chord in the source, where End is just past the chord.
+	Range Range `json:"-"`
+}
+
+// RenderChordLine lays out the chords and markers in the line at their offsets, using render for the text of each.
+// Segments that would otherwise run together, such as chords that grew longer when transposed, are kept apart by a
+// space, except for parentheses, which may touch what they group.
+func RenderChordLine(cl *ChordLine, render func(Segment) string) string {
+	var sb strings.Builder
+	currentOffset := 0
+	var prev *Segment
+	for _, seg := range cl.Segments() {
+		offsetDiff := seg.Offset - currentOffset
+		if offsetDiff > 0 {
+			sb.WriteString(strings.Repeat(" ", offsetDiff))
+			currentOffset += offsetDiff
+		} else if prev != nil && !prev.isMarker(MarkerKindGroupStart) && !seg.isMarker(MarkerKindGroupEnd) {
+			sb.WriteByte(' ')
+			currentOffset++
+		}
+
+		sb.WriteString(render(seg))
+		currentOffset += utf8.RuneCountInString(seg.Text)
+
+		seg := seg
+		prev = &seg
+	}
+
+	return sb.String()
+}
+
+// Segment is either a chord or a marker in a chord line.
+type Segment struct {
+	Offset int
+	Text   string
+
+	// Chord is set when the segment is a chord, and Marker when it is a marker.
+	Chord  *ChordOffset
+	Marker *MarkerOffset
+}
+
+func (s Segment) isMarker(kind MarkerKind) bool {
+	return s.Marker != nil && s.Marker.Marker.Kind == kind
+}
+
+func (s Segment) setOffset(offset int) {
+	if s.Chord != nil {
+		s.Chord.Offset = offset
+	} else {
+		s.Marker.Offset = offset
+	}
+}
+
+// Range is a span in the source of a song, from Start up to, but not including, End.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
 }
 
 type TextLine struct {
 	Text string `json:"text"`
+
+	Pos Position `json:"-"`
 }
 
 func (l *TextLine) line() {}
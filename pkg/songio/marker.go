@@ -0,0 +1,88 @@
+package songio
+
+import (
+	"strconv"
+	"strings"
+)
+
+// MarkerKind is the kind of notation a marker in a chord line represents.
+type MarkerKind string
+
+const (
+	MarkerKindBar         MarkerKind = "bar"
+	MarkerKindRepeatStart MarkerKind = "repeatStart"
+	MarkerKindRepeatEnd   MarkerKind = "repeatEnd"
+	MarkerKindRepeatBar   MarkerKind = "repeatBar"
+	MarkerKindBeat        MarkerKind = "beat"
+	MarkerKindNoChord     MarkerKind = "noChord"
+	MarkerKindRepeatCount MarkerKind = "repeatCount"
+	MarkerKindGroupStart  MarkerKind = "groupStart"
+	MarkerKindGroupEnd    MarkerKind = "groupEnd"
+)
+
+// Marker is notation in a chord line that is not a chord, such as a bar line or a repeat count.
+type Marker struct {
+	Kind MarkerKind `json:"kind"`
+	Text string     `json:"text"`
+	// Count is the number of times to play a repeated part, for repeat counts.
+	Count int `json:"count,omitempty"`
+}
+
+type MarkerOffset struct {
+	Marker Marker `json:"marker"`
+	Offset int    `json:"offset"`
+
+	// Range is the location of the marker in the source, where End is just past the marker.
+	Range Range `json:"-"`
+}
+
+// ParseMarker parses the text as a marker, returning false when it is not one.
+func ParseMarker(text string) (Marker, bool) {
+	var kind MarkerKind
+	switch text {
+	case "|", "||":
+		kind = MarkerKindBar
+	case "|:", "||:":
+		kind = MarkerKindRepeatStart
+	case ":|", ":||":
+		kind = MarkerKindRepeatEnd
+	case "%":
+		kind = MarkerKindRepeatBar
+	case "/":
+		kind = MarkerKindBeat
+	case "N.C.", "N.C", "NC":
+		kind = MarkerKindNoChord
+	case "(":
+		kind = MarkerKindGroupStart
+	case ")":
+		kind = MarkerKindGroupEnd
+	default:
+		if count, ok := parseRepeatCount(text); ok {
+			return Marker{Kind: MarkerKindRepeatCount, Text: text, Count: count}, true
+		}
+
+		return Marker{}, false
+	}
+
+	return Marker{Kind: kind, Text: text}, true
+}
+
+// parseRepeatCount parses repeat counts such as "x2" and "2x".
+func parseRepeatCount(text string) (int, bool) {
+	var digits string
+	switch {
+	case strings.HasPrefix(text, "x"), strings.HasPrefix(text, "X"):
+		digits = text[1:]
+	case strings.HasSuffix(text, "x"), strings.HasSuffix(text, "X"):
+		digits = text[:len(text)-1]
+	default:
+		return 0, false
+	}
+
+	count, err := strconv.Atoi(digits)
+	if err != nil || count < 1 || strings.HasPrefix(digits, "+") {
+		return 0, false
+	}
+
+	return count, true
+}
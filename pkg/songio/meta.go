@@ -1,34 +1,161 @@
 package songio
 
 import (
+	"strings"
+	"time"
+
 	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
 	"github.com/craiggwilson/songtool/pkg/theory/key"
 	"github.com/craiggwilson/songtool/pkg/theory/note"
 )
 
 type Meta struct {
-	Title    string        `json:"title"`
-	Key      *key.Named    `json:"key"`
-	Sections []string      `json:"sections"`
-	Chords   []chord.Named `json:"chords"`
+	Title     string         `json:"title"`
+	Artist    string         `json:"artist,omitempty"`
+	Album     string         `json:"album,omitempty"`
+	Year      int            `json:"year,omitempty"`
+	Tempo     int            `json:"tempo,omitempty"`
+	Time      *TimeSignature `json:"time,omitempty"`
+	Capo      int            `json:"capo,omitempty"`
+	Tags      []string       `json:"tags,omitempty"`
+	CCLI      string         `json:"ccli,omitempty"`
+	Copyright string         `json:"copyright,omitempty"`
+	Duration  time.Duration  `json:"duration,omitempty"`
+	Comments  []string       `json:"comments,omitempty"`
+	// Key is the key the song starts in.
+	Key  *key.Named  `json:"key"`
+	Keys []KeyRegion `json:"keys,omitempty"`
+	// Sections are the names of the sections defined in the song, those with content, in the order they are defined.
+	Sections []string `json:"sections"`
+	// Order is the names of the sections in the order they are performed, either from the order directive or as they
+	// appear in the song.
+	Order  []string      `json:"order,omitempty"`
+	Chords []chord.Named `json:"chords"`
+	// Difficulty rates how hard the chords are to play, from harmony.MinDifficulty to harmony.MaxDifficulty. It is
+	// only populated when the full song is read.
+	Difficulty int `json:"difficulty,omitempty"`
+
+	// Modulations are the key changes inferred from the chords, for songs without key changes of their own. They are
+	// not populated by ReadMeta.
+	Modulations []Modulation `json:"modulations,omitempty"`
+}
+
+// KeyForSection returns the key of the section at the index, in the order the sections appear in the song.
+func (m *Meta) KeyForSection(index int) *key.Named {
+	for i := range m.Keys {
+		if index < m.Keys[i].firstSection+len(m.Keys[i].Sections) {
+			return &m.Keys[i].Key
+		}
+	}
+
+	return m.Key
+}
+
+// KeyRegion is a part of the song that is in a single key. A song with key changes has a region for each key.
+type KeyRegion struct {
+	Key key.Named `json:"key"`
+	// Sections are the names of the sections that start in the region.
+	Sections []string `json:"sections,omitempty"`
+	// StartLine and EndLine are the first and last lines of the region in the source, when they are known.
+	StartLine int `json:"startLine,omitempty"`
+	EndLine   int `json:"endLine,omitempty"`
+
+	firstSection int
 }
 
 func ReadMeta(noteNamer note.Namer, src Reader, full bool) (Meta, error) {
 	var meta Meta
 
+	// starts are the names of every section in the order they appear, including those that repeat another section.
+	var starts []string
+	var order []string
+	currentSection := ""
+	defined := make(map[string]struct{})
+
+	// Key changes only start a new region once the current region has chords.
+	regionHasChords := false
+	startKeyRegion := func(k key.Named, line Line) {
+		if len(meta.Keys) > 0 && !regionHasChords {
+			meta.Keys[len(meta.Keys)-1].Key = k
+			if len(meta.Keys) == 1 {
+				meta.Key = &k
+			}
+			return
+		}
+
+		region := KeyRegion{
+			Key:          k,
+			StartLine:    PositionOf(line).Line,
+			EndLine:      PositionOf(line).Line,
+			firstSection: len(starts),
+		}
+
+		if len(meta.Keys) == 0 {
+			// The first region starts at the beginning of the song.
+			region.Sections = append(region.Sections, starts...)
+			region.firstSection = 0
+			if region.StartLine > 0 {
+				region.StartLine = 1
+			}
+			meta.Key = &k
+		}
+
+		meta.Keys = append(meta.Keys, region)
+		regionHasChords = false
+	}
+
 	chordSet := make(map[string]struct{})
+	var played []chord.Chord
+	chordLines := 0
 Loop:
 	for line, ok := src.Next(); ok; line, ok = src.Next() {
+		switch line.(type) {
+		case *ChordLine, *TextLine:
+			if _, ok := defined[strings.ToLower(currentSection)]; !ok && len(currentSection) > 0 {
+				defined[strings.ToLower(currentSection)] = struct{}{}
+				meta.Sections = append(meta.Sections, currentSection)
+			}
+		}
+
 		switch tl := line.(type) {
 		case *KeyDirectiveLine:
-			meta.Key = &tl.Key
+			startKeyRegion(tl.Key, line)
+		case *OrderDirectiveLine:
+			if order == nil {
+				order = tl.Order
+			}
 		case *TitleDirectiveLine:
 			meta.Title = tl.Title
+		case *ArtistDirectiveLine:
+			meta.Artist = tl.Artist
+		case *AlbumDirectiveLine:
+			meta.Album = tl.Album
+		case *YearDirectiveLine:
+			meta.Year = tl.Year
+		case *TempoDirectiveLine:
+			meta.Tempo = tl.BPM
+		case *TimeDirectiveLine:
+			ts := tl.Time
+			meta.Time = &ts
+		case *CapoDirectiveLine:
+			meta.Capo = tl.Fret
+		case *TagsDirectiveLine:
+			meta.Tags = append(meta.Tags, tl.Tags...)
+		case *CCLIDirectiveLine:
+			meta.CCLI = tl.Number
+		case *CopyrightDirectiveLine:
+			meta.Copyright = tl.Copyright
+		case *DurationDirectiveLine:
+			meta.Duration = tl.Duration
+		case *CommentDirectiveLine:
+			meta.Comments = append(meta.Comments, tl.Comment)
 		case *ChordLine:
 			if !full && meta.Key != nil {
 				break Loop
 			}
 
+			chordLines++
 			for _, chordOffset := range tl.Chords {
 				if meta.Key == nil {
 					kind := key.KindMajor
@@ -45,15 +172,18 @@ Loop:
 						Suffix: suffix,
 					}
 
-					meta.Key = &key.Named{
+					startKeyRegion(key.Named{
 						Parsed: metaKey,
 						Name:   metaKey.Name(noteNamer),
-					}
+					}, line)
 					if !full {
 						break Loop
 					}
 				}
 
+				regionHasChords = true
+				played = append(played, chordOffset.Chord.Chord)
+
 				name := chordOffset.Chord.Name
 				if _, ok := chordSet[name]; !ok {
 					meta.Chords = append(meta.Chords, chordOffset.Chord)
@@ -65,12 +195,48 @@ Loop:
 				break Loop
 			}
 
-			meta.Sections = append(meta.Sections, tl.Name)
-		case *TextLine, *SectionEndDirectiveLine:
+			currentSection = tl.Name
+			starts = append(starts, tl.Name)
+			if len(meta.Keys) > 0 {
+				region := &meta.Keys[len(meta.Keys)-1]
+				region.Sections = append(region.Sections, tl.Name)
+			}
+		case *SectionEndDirectiveLine:
+			if !full && meta.Key != nil {
+				break Loop
+			}
+			currentSection = ""
+		case *TextLine:
 			if !full && meta.Key != nil {
 				break Loop
 			}
 		}
+
+		if len(meta.Keys) > 0 {
+			if pos := PositionOf(line); pos.IsValid() {
+				meta.Keys[len(meta.Keys)-1].EndLine = pos.Line
+			}
+		}
+	}
+
+	meta.Order = starts
+	if order != nil {
+		meta.Order = make([]string, 0, len(order))
+		for _, entry := range order {
+			if name, ok := ResolveSection(meta.Sections, entry); ok {
+				meta.Order = append(meta.Order, name)
+			} else {
+				meta.Order = append(meta.Order, entry)
+			}
+		}
+	}
+
+	if full {
+		var k *key.Key
+		if meta.Key != nil {
+			k = &meta.Key.Key
+		}
+		meta.Difficulty = harmony.Difficulty(k, played, chordLines)
 	}
 
 	return meta, src.Err()
@@ -0,0 +1,70 @@
+package songio_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadMeta_KeyRegions(t *testing.T) {
+	testCases := []struct {
+		name            string
+		text            string
+		key             string
+		regions         []string
+		keysForSections []string
+	}{
+		{
+			name:            "key changes between sections",
+			text:            "#key=G\n[Verse]\nG   D\nla\n\n#key=A\n[Chorus]\nA   E\nlo\n\n[Verse]\n\n#key=Bb\n[Bridge]\nBb\nli\n",
+			key:             "G",
+			regions:         []string{"G [Verse] 1-4", "A [Chorus Verse] 6-11", "Bb [Bridge] 13-16"},
+			keysForSections: []string{"G", "A", "A", "Bb"},
+		},
+		{
+			name:            "key change within a section",
+			text:            "#key=C\n[Verse]\nC\nla\n#key=D\nD\nlo\n\n[Chorus]\nG\nli\n",
+			key:             "C",
+			regions:         []string{"C [Verse] 1-4", "D [Chorus] 5-11"},
+			keysForSections: []string{"C", "D"},
+		},
+		{
+			name:            "key changes before any chords",
+			text:            "#key=G\n#key=A\n[Verse]\nA\nla\n",
+			key:             "A",
+			regions:         []string{"A [Verse] 1-5"},
+			keysForSections: []string{"A"},
+		},
+		{
+			name:            "key from the first chord",
+			text:            "[Intro]\nEm  C\n\n[Verse]\nG\nla\n",
+			key:             "Em",
+			regions:         []string{"Em [Intro Verse] 1-6"},
+			keysForSections: []string{"Em", "Em"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			meta, err := songio.ReadMeta(theory.Default(), readSong(tc.text), true)
+			require.Nil(t, err)
+			require.NotNil(t, meta.Key)
+			require.Equal(t, tc.key, meta.Key.Name)
+
+			var regions []string
+			for _, r := range meta.Keys {
+				regions = append(regions, fmt.Sprintf("%s %v %d-%d", r.Key.Name, r.Sections, r.StartLine, r.EndLine))
+			}
+			require.Equal(t, tc.regions, regions)
+
+			var keysForSections []string
+			for i := range meta.Order {
+				keysForSections = append(keysForSections, meta.KeyForSection(i).Name)
+			}
+			require.Equal(t, tc.keysForSections, keysForSections)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package songio
+
+import (
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
+)
+
+// Modulation is a change of key that was inferred from the chords rather than declared with a key directive.
+type Modulation struct {
+	Key     key.Named   `json:"key"`
+	Chord   chord.Named `json:"chord"`
+	Section string      `json:"section,omitempty"`
+	Line    int         `json:"line,omitempty"`
+
+	// lineIndex and sectionIndex are the indexes of the chord line and the section start line in the song's lines,
+	// where sectionIndex is -1 when the chord line is not the first in its section.
+	lineIndex    int
+	sectionIndex int
+}
+
+// DetectModulations infers the key changes in the lines, starting from the key. When start is nil, the starting key
+// is detected from the chords.
+func DetectModulations(keyNamer key.Namer, keyParser key.Parser, lines []Line, start *key.Key, window int) ([]Modulation, error) {
+	type chordRef struct {
+		chord        chord.Named
+		section      string
+		lineIndex    int
+		sectionIndex int
+	}
+
+	var refs []chordRef
+	section := ""
+	sectionIndex := -1
+	for i, line := range lines {
+		switch tl := line.(type) {
+		case *SectionStartDirectiveLine:
+			section = tl.Name
+			sectionIndex = i
+		case *SectionEndDirectiveLine:
+			section = ""
+			sectionIndex = -1
+		case *ChordLine:
+			for _, co := range tl.Chords {
+				refs = append(refs, chordRef{co.Chord, section, i, sectionIndex})
+			}
+			sectionIndex = -1
+		}
+	}
+
+	chords := make([]chord.Chord, 0, len(refs))
+	for _, ref := range refs {
+		chords = append(chords, ref.chord.Chord)
+	}
+
+	if start == nil {
+		if window <= 0 {
+			window = harmony.DefaultWindow
+		}
+
+		detected, ok := harmony.DetectKey(chords[:minInt(window, len(chords))])
+		if !ok {
+			return nil, nil
+		}
+		start = &detected
+	}
+
+	var modulations []Modulation
+	for _, m := range harmony.DetectModulations(*start, chords, window) {
+		named, err := keyParser.ParseKey(keyNamer.NameKey(m.Key))
+		if err != nil {
+			return nil, err
+		}
+
+		ref := refs[m.Index]
+		mod := Modulation{
+			Key:          named,
+			Chord:        ref.chord,
+			Section:      ref.section,
+			Line:         PositionOf(lines[ref.lineIndex]).Line,
+			lineIndex:    ref.lineIndex,
+			sectionIndex: -1,
+		}
+
+		// Only a change at the start of a section belongs before the section.
+		if m.Index == 0 || refs[m.Index-1].lineIndex != ref.lineIndex {
+			mod.sectionIndex = ref.sectionIndex
+		}
+
+		modulations = append(modulations, mod)
+	}
+
+	return modulations, nil
+}
+
+// InsertModulations adds a key directive for each modulation, before its section when the modulation starts the
+// section, or otherwise before its chord line.
+func InsertModulations(lines []Line, modulations []Modulation) []Line {
+	insertAt := make(map[int]*KeyDirectiveLine, len(modulations))
+	for _, m := range modulations {
+		idx := m.lineIndex
+		if m.sectionIndex >= 0 {
+			idx = m.sectionIndex
+		}
+		insertAt[idx] = &KeyDirectiveLine{Key: m.Key}
+	}
+
+	result := make([]Line, 0, len(lines)+len(modulations))
+	for i, line := range lines {
+		if kdl, ok := insertAt[i]; ok {
+			result = append(result, kdl)
+		}
+		result = append(result, line)
+	}
+
+	return result
+}
+
+func minInt(a, b int) int {
+	if a <= b {
+		return a
+	}
+	return b
+}
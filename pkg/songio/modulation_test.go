@@ -0,0 +1,52 @@
+package songio_test
+
+import (
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertModulations(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "no modulation",
+			text:     "#key=C\n\n[Verse]\nC F G C\nla\nC F G C\nla\nAm F G C\nla\n",
+			expected: "#key=C\n\n[Verse]\nC F G C\nla\nC F G C\nla\nAm F G C\nla\n",
+		},
+		{
+			name:     "at the start of a section",
+			text:     "#key=C\n\n[Verse]\nC F G C\nla\nC F G C\nla\nAm F G C\nla\n\n[Chorus]\nD G A D\nlo\nBm G A D\nlo\nD G A D\nlo\n",
+			expected: "#key=C\n\n[Verse]\nC F G C\nla\nC F G C\nla\nAm F G C\nla\n\n#key=D\n[Chorus]\nD G A D\nlo\nBm G A D\nlo\nD G A D\nlo\n",
+		},
+		{
+			name:     "within a section",
+			text:     "#key=C\n\nC F G C\nla\nC F G C\nla\nAm F G C\nla\n\nD G A D\nlo\nBm G A D\nlo\nD G A D\nlo\n",
+			expected: "#key=C\n\nC F G C\nla\nC F G C\nla\nAm F G C\nla\n\n#key=D\nD G A D\nlo\nBm G A D\nlo\nD G A D\nlo\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			th := theory.Default()
+			lines, err := songio.ReadAllLines(readSong(tc.text))
+			require.Nil(t, err)
+
+			meta, err := songio.ReadMeta(th, songio.FromLines(lines), true)
+			require.Nil(t, err)
+
+			modulations, err := songio.DetectModulations(th, th, lines, &meta.Key.Key, harmony.DefaultWindow)
+			require.Nil(t, err)
+
+			lines = songio.InsertModulations(lines, modulations)
+			actual := writeSong(t, songio.Format(songio.FromLines(lines)))
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package songio
+
+import (
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+)
+
+func NormalizeChords(normalizer chord.Normalizer, src Reader) *ChordNormalizer {
+	return &ChordNormalizer{
+		normalizer: normalizer,
+		src:        src,
+	}
+}
+
+type ChordNormalizer struct {
+	normalizer chord.Normalizer
+	src        Reader
+}
+
+// Normalizer returns the normalizer used to rename the chords.
+func (s *ChordNormalizer) Normalizer() chord.Normalizer {
+	return s.normalizer
+}
+
+func (s *ChordNormalizer) Next() (Line, bool) {
+	nl, ok := s.src.Next()
+	if !ok {
+		return nl, false
+	}
+
+	if cl, ok := nl.(*ChordLine); ok {
+		for _, seg := range cl.Chords {
+			seg.Chord = s.normalizer.NormalizeChord(seg.Chord.Chord)
+		}
+	}
+
+	return nl, ok
+}
+
+func (s *ChordNormalizer) Err() error {
+	return s.src.Err()
+}
@@ -0,0 +1,97 @@
+package songio
+
+import (
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
+)
+
+// ProgressionMatch is an occurrence of a known progression in a section of the song.
+type ProgressionMatch struct {
+	Name     string        `json:"name"`
+	Numerals []string      `json:"numerals"`
+	Key      key.Named     `json:"key"`
+	Section  string        `json:"section,omitempty"`
+	Line     int           `json:"line,omitempty"`
+	Chords   []chord.Named `json:"chords"`
+}
+
+// FindProgressions finds the progressions in each section of the song, relative to the key in effect for the
+// section. The start key is used until the first key directive. A minor progression found in a major key is reported
+// in the relative minor key, and a major progression found in a minor key in the relative major key.
+func FindProgressions(keyNamer key.Namer, keyParser key.Parser, lines []Line, start key.Named, progressions []harmony.Progression) ([]ProgressionMatch, error) {
+	type chordRef struct {
+		chord     chord.Named
+		lineIndex int
+	}
+
+	var matches []ProgressionMatch
+	current := start
+	section := ""
+	var refs []chordRef
+
+	flush := func() error {
+		chords := make([]chord.Chord, 0, len(refs))
+		for _, ref := range refs {
+			chords = append(chords, ref.chord.Chord)
+		}
+
+		for _, m := range harmony.FindProgressions(current.Key, chords, progressions) {
+			k := current
+			if m.Key != current.Key {
+				named, err := keyParser.ParseKey(keyNamer.NameKey(m.Key))
+				if err != nil {
+					return err
+				}
+				k = named
+			}
+
+			match := ProgressionMatch{
+				Name:    m.Progression.Name,
+				Key:     k,
+				Section: section,
+				Line:    PositionOf(lines[refs[m.Index].lineIndex]).Line,
+			}
+			for _, n := range m.Progression.Numerals {
+				match.Numerals = append(match.Numerals, n.Text)
+			}
+			for _, ref := range refs[m.Index : m.Index+m.Count] {
+				match.Chords = append(match.Chords, ref.chord)
+			}
+			matches = append(matches, match)
+		}
+
+		refs = nil
+		return nil
+	}
+
+	for i, line := range lines {
+		switch tl := line.(type) {
+		case *KeyDirectiveLine:
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			current = tl.Key
+		case *SectionStartDirectiveLine:
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			section = tl.Name
+		case *SectionEndDirectiveLine:
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			section = ""
+		case *ChordLine:
+			for _, co := range tl.Chords {
+				refs = append(refs, chordRef{co.Chord, i})
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
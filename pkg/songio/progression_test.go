@@ -0,0 +1,32 @@
+package songio_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindProgressions(t *testing.T) {
+	progressions, err := harmony.ParseProgressions(harmony.DefaultProgressions())
+	require.Nil(t, err)
+
+	th := theory.Default()
+	lines, err := songio.ReadAllLines(readSong("#key=C\n[Verse]\nAm G F E\nla\nC Am F G\nlo\n\n#key=Am\n[Chorus]\nC Am F G\nli\n"))
+	require.Nil(t, err)
+
+	start, err := th.ParseKey("C")
+	require.Nil(t, err)
+
+	matches, err := songio.FindProgressions(th, th, lines, start, progressions)
+	require.Nil(t, err)
+
+	var actual []string
+	for _, m := range matches {
+		actual = append(actual, fmt.Sprintf("%d:%s in %s", m.Line, m.Name, m.Key.Name))
+	}
+	require.Equal(t, []string{"3:Andalusian Cadence in Am", "5:I–vi–IV–V in C", "10:I–vi–IV–V in C"}, actual)
+}
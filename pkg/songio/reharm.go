@@ -0,0 +1,159 @@
+package songio
+
+import (
+	"unicode/utf8"
+
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
+)
+
+// ChordSubstitutions are the substitutions proposed for a chord in a song.
+type ChordSubstitutions struct {
+	Chord         chord.Named    `json:"chord"`
+	Key           key.Named      `json:"key"`
+	Section       string         `json:"section,omitempty"`
+	Line          int            `json:"line,omitempty"`
+	Substitutions []Substitution `json:"substitutions"`
+
+	lineIndex  int
+	chordIndex int
+}
+
+// Substitution is a chord proposed in place of, or before, a chord in a song.
+type Substitution struct {
+	Rule  harmony.Rule `json:"rule"`
+	Chord chord.Named  `json:"chord"`
+	// Before indicates the chord is played before the original chord rather than instead of it.
+	Before bool `json:"before,omitempty"`
+}
+
+// SuggestSubstitutions proposes substitutions for each chord in the lines, relative to the key in effect for the
+// chord. The start key is used until the first key directive.
+func SuggestSubstitutions(normalizer chord.Normalizer, lines []Line, start key.Named) []ChordSubstitutions {
+	var result []ChordSubstitutions
+	current := start
+	section := ""
+	var pending []ChordSubstitutions
+
+	flush := func() {
+		chords := make([]chord.Chord, 0, len(pending))
+		for _, cs := range pending {
+			chords = append(chords, cs.Chord.Chord)
+		}
+
+		for i, subs := range harmony.Reharmonize(current.Key, chords) {
+			for _, sub := range subs {
+				pending[i].Substitutions = append(pending[i].Substitutions, Substitution{
+					Rule:   sub.Rule,
+					Chord:  normalizer.NormalizeChord(sub.Chord),
+					Before: sub.Before,
+				})
+			}
+		}
+
+		result = append(result, pending...)
+		pending = nil
+	}
+
+	for i, line := range lines {
+		switch tl := line.(type) {
+		case *KeyDirectiveLine:
+			flush()
+			current = tl.Key
+		case *SectionStartDirectiveLine:
+			section = tl.Name
+		case *SectionEndDirectiveLine:
+			section = ""
+		case *ChordLine:
+			for j, co := range tl.Chords {
+				pending = append(pending, ChordSubstitutions{
+					Chord:      co.Chord,
+					Key:        current,
+					Section:    section,
+					Line:       PositionOf(line).Line,
+					lineIndex:  i,
+					chordIndex: j,
+				})
+			}
+		}
+	}
+	flush()
+
+	return result
+}
+
+// ApplySubstitutions rewrites the chords in the lines, using for each chord the first of its substitutions that
+// follows one of the rules, trying the rules in order. Chords played before another chord are placed just before it,
+// moving it to the right when there is not enough room.
+func ApplySubstitutions(lines []Line, substitutions []ChordSubstitutions, rules []harmony.Rule) []Line {
+	chosen := make(map[int]map[int]Substitution)
+	for _, cs := range substitutions {
+		if sub, ok := chooseSubstitution(cs.Substitutions, rules); ok {
+			if _, ok := chosen[cs.lineIndex]; !ok {
+				chosen[cs.lineIndex] = make(map[int]Substitution)
+			}
+			chosen[cs.lineIndex][cs.chordIndex] = sub
+		}
+	}
+
+	result := make([]Line, 0, len(lines))
+	for i, line := range lines {
+		subs, ok := chosen[i]
+		if !ok {
+			result = append(result, line)
+			continue
+		}
+
+		cl := line.(*ChordLine)
+		rewritten := &ChordLine{Markers: cl.Markers, Pos: cl.Pos}
+		end := 0
+		for j, co := range cl.Chords {
+			offset := co.Offset
+			if offset < end {
+				offset = end
+			}
+
+			sub, ok := subs[j]
+			switch {
+			case !ok:
+				rewritten.Chords = append(rewritten.Chords, &ChordOffset{Chord: co.Chord, Offset: offset, Range: co.Range})
+			case sub.Before:
+				width := utf8.RuneCountInString(sub.Chord.Name)
+				before := offset - width - 1
+				if before < end {
+					before = end
+				}
+				if offset < before+width+1 {
+					offset = before + width + 1
+				}
+
+				rewritten.Chords = append(rewritten.Chords,
+					&ChordOffset{Chord: sub.Chord, Offset: before},
+					&ChordOffset{Chord: co.Chord, Offset: offset, Range: co.Range},
+				)
+			default:
+				rewritten.Chords = append(rewritten.Chords, &ChordOffset{Chord: sub.Chord, Offset: offset, Range: co.Range})
+			}
+
+			last := rewritten.Chords[len(rewritten.Chords)-1]
+			end = last.Offset + utf8.RuneCountInString(last.Chord.Name) + 1
+		}
+
+		result = append(result, rewritten)
+	}
+
+	return result
+}
+
+func chooseSubstitution(subs []Substitution, rules []harmony.Rule) (Substitution, bool) {
+	for _, rule := range rules {
+		for _, sub := range subs {
+			if sub.Rule == rule {
+				return sub, true
+			}
+		}
+	}
+
+	return Substitution{}, false
+}
@@ -0,0 +1,40 @@
+package songio
+
+import (
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+)
+
+// Simplify reduces the chords in the song to simpler forms, naming them with the normalizer. See chord.Simplify for
+// the levels.
+func Simplify(normalizer chord.Normalizer, src Reader, level int) *ChordSimplifier {
+	return &ChordSimplifier{
+		normalizer: normalizer,
+		src:        src,
+		level:      level,
+	}
+}
+
+type ChordSimplifier struct {
+	normalizer chord.Normalizer
+	src        Reader
+	level      int
+}
+
+func (s *ChordSimplifier) Next() (Line, bool) {
+	nl, ok := s.src.Next()
+	if !ok {
+		return nl, false
+	}
+
+	if cl, ok := nl.(*ChordLine); ok {
+		for _, seg := range cl.Chords {
+			seg.Chord = s.normalizer.NormalizeChord(chord.Simplify(seg.Chord.Chord, s.level))
+		}
+	}
+
+	return nl, ok
+}
+
+func (s *ChordSimplifier) Err() error {
+	return s.src.Err()
+}
@@ -1,6 +1,11 @@
 package songio
 
-import "strings"
+import (
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+)
 
 type Reader interface {
 	Next() (Line, bool)
@@ -44,3 +49,104 @@ func (s *chordsRemover) Next() (Line, bool) {
 func (s *chordsRemover) Err() error {
 	return s.src.Err()
 }
+
+// FilterSections keeps only the sections whose names match one of the include patterns, or every section when there
+// are none, and that do not match one of the exclude patterns. The patterns are globs, such as "Chorus*", and are
+// matched without regard to case, either against the whole name or against the name without its number, so "verse"
+// matches "Verse 2". Directives outside the sections, such as the key, are kept, while any other content outside the
+// sections is removed.
+func FilterSections(src Reader, include, exclude []string) Reader {
+	return &sectionFilter{
+		src:     src,
+		include: include,
+		exclude: exclude,
+	}
+}
+
+type sectionFilter struct {
+	src     Reader
+	include []string
+	exclude []string
+
+	inSection   bool
+	keep        bool
+	seenSection bool
+	err         error
+}
+
+func (s *sectionFilter) Next() (Line, bool) {
+	for line, ok := s.src.Next(); ok; line, ok = s.src.Next() {
+		switch tl := line.(type) {
+		case *SectionStartDirectiveLine:
+			s.inSection = true
+			s.seenSection = true
+			s.keep = s.matches(tl.Name)
+			if s.keep {
+				return line, true
+			}
+		case *SectionEndDirectiveLine:
+			keep := s.keep
+			s.inSection = false
+			s.keep = false
+			if keep {
+				return line, true
+			}
+		case *OrderDirectiveLine:
+			// The order would refer to sections that were removed.
+		case EmptyLine:
+			if s.keep || !s.seenSection {
+				return line, true
+			}
+		case *ChordLine, *TextLine, *CommentDirectiveLine:
+			if s.keep || (!s.inSection && isComment(line)) {
+				return line, true
+			}
+		default:
+			return line, true
+		}
+	}
+
+	return nil, false
+}
+
+func isComment(line Line) bool {
+	_, ok := line.(*CommentDirectiveLine)
+	return ok
+}
+
+func (s *sectionFilter) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+
+	return s.src.Err()
+}
+
+func (s *sectionFilter) matches(name string) bool {
+	included := len(s.include) == 0
+	if !included {
+		included = s.matchAny(s.include, name)
+	}
+
+	return included && !s.matchAny(s.exclude, name)
+}
+
+func (s *sectionFilter) matchAny(patterns []string, name string) bool {
+	name = strings.ToLower(name)
+	unnumbered := strings.TrimSpace(strings.TrimRightFunc(name, unicode.IsDigit))
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		for _, candidate := range []string{name, unnumbered} {
+			ok, err := path.Match(pattern, candidate)
+			if err != nil {
+				s.err = fmt.Errorf("invalid section pattern %q: %w", pattern, err)
+				return false
+			}
+			if ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,46 @@
+package songio_test
+
+import (
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterSections(t *testing.T) {
+	text := "#title=Song\n#key=G\n[Verse 1]\nlo\n\n[Chorus]\nla\n\n[Verse 2]\nli\n\n[Bridge]\nle\n"
+
+	testCases := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected string
+	}{
+		{
+			name:     "include by name",
+			include:  []string{"chorus"},
+			expected: "#title=Song\n#key=G\n[Chorus]\nla\n\n",
+		},
+		{
+			name:     "include without number",
+			include:  []string{"verse"},
+			expected: "#title=Song\n#key=G\n[Verse 1]\nlo\n\n[Verse 2]\nli\n\n",
+		},
+		{
+			name:     "include by glob",
+			include:  []string{"B*"},
+			expected: "#title=Song\n#key=G\n[Bridge]\nle\n\n",
+		},
+		{
+			name:     "exclude",
+			exclude:  []string{"verse*", "bridge"},
+			expected: "#title=Song\n#key=G\n[Chorus]\nla\n\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, writeSong(t, songio.FilterSections(readSong(text), tc.include, tc.exclude)))
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package songio
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ReadSongs splits a songbook, a stream with more than one song, into its songs. Songs are separated by song breaks, and
+// a stream without any is a single song. A second title directive in a song doesn't start a new one, since it is more
+// likely a mistake than a song without a break before it.
+func ReadSongs(src Reader) *SongsReader {
+	return &SongsReader{
+		src: src,
+	}
+}
+
+type SongsReader struct {
+	src  Reader
+	done bool
+}
+
+// Next returns the next song in the songbook. Songs without any content, such as before a leading song break, are
+// skipped.
+func (r *SongsReader) Next() (*Lines, bool) {
+	for !r.done {
+		lines := r.readSong()
+		for _, line := range lines {
+			if _, ok := line.(EmptyLine); !ok {
+				return FromLines(lines), true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (r *SongsReader) Err() error {
+	return r.src.Err()
+}
+
+func (r *SongsReader) readSong() []Line {
+	var lines []Line
+	for line, ok := r.src.Next(); ok; line, ok = r.src.Next() {
+		if _, ok := line.(*SongBreakLine); ok {
+			return lines
+		}
+
+		lines = append(lines, line)
+	}
+
+	r.done = true
+	return lines
+}
+
+// SelectSong returns the song in the songbook chosen by the selector, which is either the song's number, starting at 1,
+// or its title, ignoring case.
+func SelectSong(src Reader, selector string) Reader {
+	return &songSelector{
+		src:      src,
+		selector: selector,
+	}
+}
+
+type songSelector struct {
+	src      Reader
+	selector string
+
+	song Reader
+	err  error
+}
+
+func (s *songSelector) Next() (Line, bool) {
+	if s.song == nil && s.err == nil {
+		s.song, s.err = s.selectSong()
+	}
+
+	if s.err != nil {
+		return nil, false
+	}
+
+	return s.song.Next()
+}
+
+func (s *songSelector) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+
+	return s.src.Err()
+}
+
+func (s *songSelector) selectSong() (Reader, error) {
+	number, err := strconv.Atoi(s.selector)
+	if err != nil {
+		number = 0
+	}
+
+	songs := ReadSongs(s.src)
+	for i := 1; ; i++ {
+		song, ok := songs.Next()
+		if !ok {
+			break
+		}
+
+		if i == number {
+			return song, nil
+		}
+
+		for _, line := range song.Lines() {
+			if tdl, ok := line.(*TitleDirectiveLine); ok && strings.EqualFold(strings.TrimSpace(tdl.Title), strings.TrimSpace(s.selector)) {
+				song.Rewind()
+				return song, nil
+			}
+		}
+	}
+
+	if err := songs.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("no song matches %q", s.selector)
+}
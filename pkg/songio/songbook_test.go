@@ -0,0 +1,105 @@
+package songio_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSongs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected [][]string
+	}{
+		{
+			name:     "single song",
+			text:     "#title=One\n[Verse]\nla\n",
+			expected: [][]string{{"One"}},
+		},
+		{
+			name:     "song breaks",
+			text:     "#title=One\n[Verse]\nla\n---\n#title=Two\n---\n#title=Three\n",
+			expected: [][]string{{"One"}, {"Two"}, {"Three"}},
+		},
+		{
+			name:     "leading song break",
+			text:     "---\n#title=One\n",
+			expected: [][]string{{"One"}},
+		},
+		{
+			name:     "dashes in a single song",
+			text:     "#title=One\n[Verse]\nla\n---\nlo\n-----\n",
+			expected: [][]string{{"One"}},
+		},
+		{
+			name:     "dashes after a song without a title",
+			text:     "[Verse]\nla\n---\n#title=Two\n",
+			expected: [][]string{{"Two"}},
+		},
+		{
+			name:     "title after other directives",
+			text:     "#title=One\n---\n\n#artist=Someone\n#title=Two\n",
+			expected: [][]string{{"One"}, {"Two"}},
+		},
+		{
+			name:     "title after content",
+			text:     "#title=One\n---\nla\n#title=Two\n",
+			expected: [][]string{{"One", "Two"}},
+		},
+		{
+			name:     "duplicate title",
+			text:     "#title=One\n[Verse]\nla\n#title=Again\n[Chorus]\nlo\n",
+			expected: [][]string{{"One", "Again"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			th := theory.Default()
+			songs := songio.ReadSongs(songio.ReadChordsOverLyrics(th, th, strings.NewReader(tc.text)))
+
+			var actual [][]string
+			for song, ok := songs.Next(); ok; song, ok = songs.Next() {
+				var titles []string
+				for _, line := range song.Lines() {
+					if tdl, ok := line.(*songio.TitleDirectiveLine); ok {
+						titles = append(titles, tdl.Title)
+					}
+				}
+				actual = append(actual, titles)
+			}
+			require.Nil(t, songs.Err())
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestSelectSong(t *testing.T) {
+	text := "#title=One\n---\n#title=Two\n---\n#title=Three\n"
+
+	testCases := []struct {
+		selector string
+		expected string
+	}{
+		{selector: "2", expected: "Two"},
+		{selector: "three", expected: "Three"},
+		{selector: " One ", expected: "One"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.selector, func(t *testing.T) {
+			th := theory.Default()
+			lines, err := songio.ReadAllLines(songio.SelectSong(songio.ReadChordsOverLyrics(th, th, strings.NewReader(text)), tc.selector))
+			require.Nil(t, err)
+			require.Equal(t, tc.expected, lines[0].(*songio.TitleDirectiveLine).Title)
+		})
+	}
+
+	th := theory.Default()
+	_, err := songio.ReadAllLines(songio.SelectSong(songio.ReadChordsOverLyrics(th, th, strings.NewReader(text)), "4"))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,23 @@
+package songio_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/songio"
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/stretchr/testify/require"
+)
+
+func readSong(text string) songio.Reader {
+	th := theory.Default()
+	return songio.ReadChordsOverLyrics(th, th, strings.NewReader(text))
+}
+
+func writeSong(t *testing.T, src songio.Reader) string {
+	var sb strings.Builder
+	_, err := songio.WriteChordsOverLyrics(theory.Default(), src, &sb)
+	require.Nil(t, err)
+	require.Nil(t, src.Err())
+	return sb.String()
+}
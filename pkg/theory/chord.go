@@ -4,10 +4,18 @@ import (
 	"github.com/craiggwilson/songtool/pkg/theory/chord"
 )
 
+func AlternativeChords(c chord.Chord) []chord.Chord {
+	return std.AlternativeChords(c)
+}
+
 func NameChord(c chord.Chord) string {
 	return std.NameChord(c)
 }
 
+func NormalizeChord(c chord.Chord) chord.Named {
+	return std.NormalizeChord(c)
+}
+
 func ParseChord(text string) (chord.Named, error) {
 	return std.ParseChord(text)
 }
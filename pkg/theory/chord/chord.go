@@ -25,6 +25,22 @@ func (c Chord) Intervals() []interval.Interval {
 	return c.intervals
 }
 
+// Inversion reports which chord tone is the base note. Chords without a base note, or whose base note is the root,
+// are in root position. When the base note is not a chord tone, InversionSlash is returned.
+func (c Chord) Inversion() Inversion {
+	if c.base == nil {
+		return InversionRoot
+	}
+
+	for _, ival := range c.intervals {
+		if c.root.Transpose(ival).PitchClass() == c.base.PitchClass() {
+			return diatonicToInversion[ival.Diatonic()%7]
+		}
+	}
+
+	return InversionSlash
+}
+
 func (c Chord) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
 		Root      note.Note           `json:"root"`
@@ -37,6 +53,15 @@ func (c Chord) Name(namer Namer) string {
 	return namer.NameChord(c)
 }
 
+func (c Chord) Notes() []note.Note {
+	notes := make([]note.Note, 0, len(c.intervals))
+	for _, ival := range c.intervals {
+		notes = append(notes, c.root.Transpose(ival))
+	}
+
+	return notes
+}
+
 func (c Chord) Quality() Quality {
 	major3rd := false
 	minor3rd := false
@@ -3,6 +3,7 @@ package chord_test
 import (
 	"testing"
 
+	"github.com/craiggwilson/songtool/pkg/theory"
 	"github.com/craiggwilson/songtool/pkg/theory/chord"
 	"github.com/craiggwilson/songtool/pkg/theory/interval"
 	"github.com/craiggwilson/songtool/pkg/theory/note"
@@ -120,3 +121,88 @@ func TestChord_Quality(t *testing.T) {
 	}
 
 }
+
+func TestChord_Inversion(t *testing.T) {
+	triad := []interval.Interval{
+		interval.Perfect(0),
+		interval.Major(2),
+		interval.Perfect(4),
+	}
+
+	testCases := []struct {
+		name     string
+		chord    chord.Chord
+		expected chord.Inversion
+	}{
+		{
+			name:     "no base",
+			chord:    chord.New(note.C, nil, triad...),
+			expected: chord.InversionRoot,
+		},
+		{
+			name:     "root in base",
+			chord:    chord.New(note.C, &note.C, triad...),
+			expected: chord.InversionRoot,
+		},
+		{
+			name:     "3rd in base",
+			chord:    chord.New(note.C, &note.E, triad...),
+			expected: chord.InversionFirst,
+		},
+		{
+			name:     "5th in base",
+			chord:    chord.New(note.C, &note.G, triad...),
+			expected: chord.InversionSecond,
+		},
+		{
+			name:     "7th in base",
+			chord:    chord.New(note.C, &note.BFlat, append(triad, interval.Minor(6))...),
+			expected: chord.InversionThird,
+		},
+		{
+			name:     "9th in base",
+			chord:    chord.New(note.C, &note.D, append(triad, interval.Minor(6), interval.Major(8))...),
+			expected: chord.Inversion(4),
+		},
+		{
+			name:     "non-chord tone in base",
+			chord:    chord.New(note.C, &note.D, triad...),
+			expected: chord.InversionSlash,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.chord.Inversion())
+		})
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	testCases := []struct {
+		chord    string
+		expected [4]string
+	}{
+		{chord: "Cmaj9(#11)", expected: [4]string{"Cmaj9#11", "Cmaj7", "C", "C"}},
+		{chord: "G7/B", expected: [4]string{"G7/B", "G7/B", "G", "G"}},
+		{chord: "Dsus4", expected: [4]string{"Dsus", "Dsus", "D", "D"}},
+		{chord: "Cadd9", expected: [4]string{"Cadd9", "C", "C", "C"}},
+		{chord: "Am7", expected: [4]string{"Am7", "Am7", "Am", "Am"}},
+		{chord: "Bm7b5", expected: [4]string{"Bm7b5", "Bm7b5", "Bdim", "Bm"}},
+		{chord: "C7b5", expected: [4]string{"C7b5", "C7", "C", "C"}},
+		{chord: "Caug", expected: [4]string{"Caug", "Caug", "Caug", "C"}},
+		{chord: "E5", expected: [4]string{"E5", "E5", "E", "E"}},
+		{chord: "C6", expected: [4]string{"C6", "C", "C", "C"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.chord, func(t *testing.T) {
+			c, err := theory.ParseChord(tc.chord)
+			require.Nil(t, err)
+
+			for level, expected := range tc.expected {
+				require.Equal(t, expected, theory.NameChord(chord.Simplify(c.Chord, level)), "level %d", level)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package chord
+
+// Inversion indicates which chord tone is sounding in the bass. Tones are counted in stacked thirds, so a 9th in the
+// bass is the 4th inversion.
+type Inversion int
+
+const (
+	InversionSlash Inversion = iota - 1
+	InversionRoot
+	InversionFirst
+	InversionSecond
+	InversionThird
+)
+
+var diatonicToInversion = [7]Inversion{0, 4, 1, 5, 2, 6, 3}
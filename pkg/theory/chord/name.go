@@ -11,6 +11,10 @@ type Namer interface {
 	NameChord(Chord) string
 }
 
+type Normalizer interface {
+	NormalizeChord(Chord) Named
+}
+
 type Named struct {
 	Parsed
 
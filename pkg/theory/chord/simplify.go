@@ -0,0 +1,84 @@
+package chord
+
+import (
+	"github.com/craiggwilson/songtool/pkg/theory/interval"
+)
+
+const (
+	// SimplifyExtensions drops extensions, added tones, and alterations, leaving at most a seventh chord.
+	SimplifyExtensions = 1
+	// SimplifyTriads also drops sevenths and slash basses, and replaces suspended chords with major triads.
+	SimplifyTriads = 2
+	// SimplifyMajorMinor also replaces diminished triads with minor triads and augmented triads with major triads.
+	SimplifyMajorMinor = 3
+)
+
+// Simplify reduces the chord to a simpler form. Each level includes the simplifications of the levels below it, and
+// a level of 0 or less leaves the chord as it is.
+func Simplify(c Chord, level int) Chord {
+	if level <= 0 {
+		return c
+	}
+
+	var third, fifth, seventh *interval.Interval
+	var suspended []interval.Interval
+	var fifths []interval.Interval
+	for i := range c.intervals {
+		ival := c.intervals[i]
+		switch ival.Diatonic() {
+		case 1, 3:
+			suspended = append(suspended, ival)
+		case 2:
+			third = &ival
+		case 4:
+			fifths = append(fifths, ival)
+		case 6:
+			seventh = &ival
+		}
+	}
+
+	// A fifth altered both ways, or lowered under a major third, is an alteration rather than the chord's quality.
+	switch {
+	case len(fifths) == 1 && !(fifths[0].Chromatic() == 6 && third != nil && third.Chromatic() == 4):
+		fifth = &fifths[0]
+	case len(fifths) > 0:
+		p5 := interval.Perfect(4)
+		fifth = &p5
+	}
+
+	base := c.base
+	if level >= SimplifyTriads {
+		base = nil
+		seventh = nil
+		suspended = nil
+		if third == nil {
+			m3 := interval.Major(2)
+			third = &m3
+		}
+		if fifth == nil {
+			p5 := interval.Perfect(4)
+			fifth = &p5
+		}
+	}
+
+	if level >= SimplifyMajorMinor && fifth.Chromatic() != 7 {
+		p5 := interval.Perfect(4)
+		fifth = &p5
+	}
+
+	intervals := []interval.Interval{interval.Perfect(0)}
+	if third != nil {
+		intervals = append(intervals, *third)
+	} else {
+		// Suspended tones only stand in for a missing third.
+		intervals = append(intervals, suspended...)
+	}
+	if fifth != nil {
+		intervals = append(intervals, *fifth)
+	}
+	if seventh != nil {
+		intervals = append(intervals, *seventh)
+	}
+
+	return New(c.root, base, intervals...)
+}
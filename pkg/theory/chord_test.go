@@ -505,3 +505,210 @@ func TestRoundtripChord(t *testing.T) {
 		})
 	}
 }
+
+func TestNameChord_Base(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected string
+	}{
+		{
+			text:     "C/E",
+			expected: "C/E",
+		},
+		{
+			text:     "C/C",
+			expected: "C",
+		},
+		{
+			text:     "Am7/C",
+			expected: "Am7/C",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			parsed, err := theory.ParseChord(tc.text)
+			require.Nil(t, err)
+			require.Equal(t, tc.expected, theory.NameChord(parsed.Chord))
+		})
+	}
+}
+
+func TestNameChord_Style(t *testing.T) {
+	testCases := []struct {
+		text      string
+		pop       string
+		jazz      string
+		classical string
+	}{
+		{
+			text:      "Cmaj7",
+			pop:       "Cmaj7",
+			jazz:      "CΔ7",
+			classical: "CM7",
+		},
+		{
+			text:      "Cm7",
+			pop:       "Cm7",
+			jazz:      "C-7",
+			classical: "Cm7",
+		},
+		{
+			text:      "Cm7b5",
+			pop:       "Cm7b5",
+			jazz:      "Cø7",
+			classical: "Cø7",
+		},
+		{
+			text:      "Cø7",
+			pop:       "Cm7b5",
+			jazz:      "Cø7",
+			classical: "Cø7",
+		},
+		{
+			text:      "Cdim7",
+			pop:       "Cdim7",
+			jazz:      "C°7",
+			classical: "C°7",
+		},
+		{
+			text:      "Caug",
+			pop:       "Caug",
+			jazz:      "C+",
+			classical: "C+",
+		},
+		{
+			text:      "C7alt",
+			pop:       "Caug7b5b9#9",
+			jazz:      "C7alt",
+			classical: "C+7b5b9#9",
+		},
+		{
+			text:      "C7b9#9/E",
+			pop:       "C7b9#9/E",
+			jazz:      "C7b9#9/E",
+			classical: "C7b9#9/E",
+		},
+		{
+			text:      "G7b5b9/B",
+			pop:       "G7b5b9/B",
+			jazz:      "G7b5b9/B",
+			classical: "G7b5b9/B",
+		},
+		{
+			text:      "G7alt/B",
+			pop:       "Gaug7b5b9#9/B",
+			jazz:      "G7alt/B",
+			classical: "G+7b5b9#9/B",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			parsed, err := theory.ParseChord(tc.text)
+			require.Nil(t, err)
+
+			for style, expected := range map[string]string{"pop": tc.pop, "jazz": tc.jazz, "classical": tc.classical} {
+				th, err := theory.Default().WithChordStyle(style)
+				require.Nil(t, err)
+				require.Equal(t, expected, th.NameChord(parsed.Chord), style)
+
+				reparsed, err := th.ParseChord(expected)
+				require.Nil(t, err)
+				require.Equal(t, parsed.Chord.Intervals(), reparsed.Chord.Intervals(), style)
+			}
+		})
+	}
+}
+
+func TestParseChord_Altered(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected string
+	}{
+		{
+			text:     "C7alt",
+			expected: "Caug7b5b9#9",
+		},
+		{
+			text:     "G7alt/B",
+			expected: "Gaug7b5b9#9/B",
+		},
+		{
+			text: "Cmaj7alt",
+		},
+		{
+			text: "Csus4alt",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			parsed, err := theory.ParseChord(tc.text)
+			if len(tc.expected) == 0 {
+				require.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.expected, theory.NameChord(parsed.Chord))
+		})
+	}
+}
+
+func TestNormalizeChord(t *testing.T) {
+	th, err := theory.Default().WithChordStyle("jazz")
+	require.Nil(t, err)
+
+	parsed, err := th.ParseChord("Dm7b5/C")
+	require.Nil(t, err)
+
+	normalized := th.NormalizeChord(parsed.Chord)
+	require.Equal(t, "Dø7/C", normalized.Name)
+	require.Equal(t, "ø7", normalized.Suffix)
+	require.Equal(t, "/", normalized.BaseNoteDelimiter)
+
+	_, err = theory.Default().WithChordStyle("bebop")
+	require.EqualError(t, err, `unknown chord style "bebop"`)
+}
+
+func TestAlternativeChords(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected []string
+	}{
+		{
+			text:     "C",
+			expected: nil,
+		},
+		{
+			text:     "C/E",
+			expected: nil,
+		},
+		{
+			text:     "Am7/C",
+			expected: []string{"C6"},
+		},
+		{
+			text:     "C6",
+			expected: []string{"Am7/C"},
+		},
+		{
+			text:     "Em7/G",
+			expected: []string{"G6"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			parsed, err := theory.ParseChord(tc.text)
+			require.Nil(t, err)
+
+			var actual []string
+			for _, alt := range theory.AlternativeChords(parsed.Chord) {
+				actual = append(actual, theory.NameChord(alt))
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -1,56 +1,149 @@
 package theory
 
 import (
+	"fmt"
 	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/craiggwilson/songtool/pkg/theory/interval"
 )
 
 func DefaultConfigBase() ConfigBase {
 	return ConfigBase{
-		NaturalNoteNames:   [7]string{"C", "D", "E", "F", "G", "A", "B"},
-		SharpSymbols:       []string{"#"},
-		FlatSymbols:        []string{"b"},
-		MajorSymbols:       []string{"maj", "M"},
-		MinorSymbols:       []string{"m", "-"},
-		AugmentedSymbols:   []string{"aug", "+"},
-		DiminishedSymbols:  []string{"dim", "°", "o"},
-		BaseNoteDelimiters: []string{"/"},
+		NaturalNoteNames:      [7]string{"C", "D", "E", "F", "G", "A", "B"},
+		SharpSymbols:          []string{"#"},
+		FlatSymbols:           []string{"b"},
+		MajorSymbols:          []string{"maj", "M", "Δ"},
+		MinorSymbols:          []string{"m", "-"},
+		AugmentedSymbols:      []string{"aug", "+"},
+		DiminishedSymbols:     []string{"dim", "°", "o"},
+		HalfDiminishedSymbols: []string{"ø"},
+		BaseNoteDelimiters:    []string{"/"},
 		Scales: map[string][]interval.Interval{
 			"Major":     interval.Scales.Ionian,
 			"Ionian":    interval.Scales.Ionian,
 			"Chromatic": interval.Scales.Chromatic,
 		},
+		ChordStyles: DefaultChordStyles(),
 	}
 }
 
 func DefaultConfig() *Config {
-	return NewConfig(DefaultConfigBase())
+	cfg, err := NewConfig(DefaultConfigBase())
+	if err != nil {
+		panic(err)
+	}
+
+	return cfg
 }
 
-func NewConfig(base ConfigBase) *Config {
-	return &Config{
+// NewConfig builds a Config from the base, merging any extra chord modifiers and aliases with the built-in ones.
+func NewConfig(base ConfigBase) (*Config, error) {
+	if err := validateSymbols(base); err != nil {
+		return nil, err
+	}
+
+	extraModifiers, err := BuildExtraChordModifiers(base)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
 		ConfigBase:     base,
-		ChordModifiers: BuildChordModifiers(base),
+		ChordModifiers: append(BuildChordModifiers(base), extraModifiers...),
+	}
+
+	if err := cfg.buildChordAliases(); err != nil {
+		return nil, err
 	}
+
+	if len(base.ChordStyle) > 0 {
+		if _, ok := base.ChordStyles[base.ChordStyle]; !ok {
+			return nil, fmt.Errorf("chordStyle: unknown chord style %q", base.ChordStyle)
+		}
+	}
+
+	return cfg, nil
+}
+
+// validateSymbols ensures each kind of symbol has at least one, and that none are blank, since an empty symbol
+// would match every chord.
+func validateSymbols(base ConfigBase) error {
+	lists := []struct {
+		name    string
+		symbols []string
+	}{
+		{"sharpSymbols", base.SharpSymbols},
+		{"flatSymbols", base.FlatSymbols},
+		{"majorSymbols", base.MajorSymbols},
+		{"minorSymbols", base.MinorSymbols},
+		{"augmentedSymbols", base.AugmentedSymbols},
+		{"diminishedSymbols", base.DiminishedSymbols},
+		{"halfDiminishedSymbols", base.HalfDiminishedSymbols},
+		{"baseNoteDelimiters", base.BaseNoteDelimiters},
+	}
+
+	for _, l := range lists {
+		if len(l.symbols) == 0 {
+			return fmt.Errorf("%s: at least one symbol is required", l.name)
+		}
+
+		for i, symbol := range l.symbols {
+			if len(strings.TrimSpace(symbol)) == 0 {
+				return fmt.Errorf("%s[%d]: symbols cannot be blank", l.name, i)
+			}
+		}
+	}
+
+	return nil
 }
 
 type ConfigBase struct {
-	NaturalNoteNames   [7]string                      `json:"naturalNoteNames"`
-	SharpSymbols       []string                       `json:"sharpSymbols"`
-	FlatSymbols        []string                       `json:"flatSymbols"`
-	MajorSymbols       []string                       `json:"majorSymbols"`
-	MinorSymbols       []string                       `json:"minorSymbols"`
-	AugmentedSymbols   []string                       `json:"augmentedSymbols"`
-	DiminishedSymbols  []string                       `json:"diminishedSymbols"`
-	BaseNoteDelimiters []string                       `json:"baseNoteDelimiters"`
-	Scales             map[string][]interval.Interval `json:"scales"`
+	NaturalNoteNames      [7]string                      `json:"naturalNoteNames"`
+	SharpSymbols          []string                       `json:"sharpSymbols"`
+	FlatSymbols           []string                       `json:"flatSymbols"`
+	MajorSymbols          []string                       `json:"majorSymbols"`
+	MinorSymbols          []string                       `json:"minorSymbols"`
+	AugmentedSymbols      []string                       `json:"augmentedSymbols"`
+	DiminishedSymbols     []string                       `json:"diminishedSymbols"`
+	HalfDiminishedSymbols []string                       `json:"halfDiminishedSymbols"`
+	BaseNoteDelimiters    []string                       `json:"baseNoteDelimiters"`
+	Scales                map[string][]interval.Interval `json:"scales"`
+
+	// ChordStyle is the name of the chord style used to name chords. When empty, chords are named with the first of
+	// each kind of symbol, as in the pop style.
+	ChordStyle string `json:"chordStyle,omitempty"`
+	// ChordStyles are the named chord styles that may be selected.
+	ChordStyles map[string]ChordStyle `json:"chordStyles"`
+
+	// ChordAliases maps a symbol to the standard suffix it stands for, such as "Δ" to "maj7".
+	ChordAliases map[string]string `json:"chordAliases,omitempty"`
+	// ExtraChordModifiers are applied after the built-in chord modifiers.
+	ExtraChordModifiers []ChordModifierDefinition `json:"extraChordModifiers,omitempty"`
 }
 
 type Config struct {
 	ConfigBase
 
 	ChordModifiers []ChordModifier `json:"chordModifiers"`
+
+	chordAliases []chordAlias
+}
+
+// ChordModifierDefinition is the configurable form of a ChordModifier, where the expressions have not yet been
+// compiled.
+type ChordModifierDefinition struct {
+	Name   string              `json:"name"`
+	Match  string              `json:"match"`
+	Except string              `json:"except,omitempty"`
+	Add    []interval.Interval `json:"add,omitempty"`
+	Remove []interval.Interval `json:"remove,omitempty"`
+}
+
+type chordAlias struct {
+	symbol string
+	suffix string
 }
 
 type ChordModifier struct {
@@ -62,7 +155,7 @@ type ChordModifier struct {
 }
 
 func BuildChordModifiers(cfg ConfigBase) []ChordModifier {
-	return []ChordModifier{
+	modifiers := []ChordModifier{
 		{
 			Name: "Base",
 			Add:  []interval.Interval{interval.Perfect(0), interval.Major(2), interval.Perfect(4)},
@@ -74,6 +167,19 @@ func BuildChordModifiers(cfg ConfigBase) []ChordModifier {
 			Add:    []interval.Interval{interval.Minor(2)},
 			Remove: []interval.Interval{interval.Major(2)},
 		},
+	}
+
+	// Without any symbols, the half diminished modifier would match every suffix.
+	if halfDiminished := regexOr(cfg.HalfDiminishedSymbols); len(halfDiminished) > 0 {
+		modifiers = append(modifiers, ChordModifier{
+			Name:   "Half Diminished",
+			Match:  regexp.MustCompile("^(?:" + halfDiminished + ")7?"),
+			Add:    []interval.Interval{interval.Minor(2), interval.Diminished(4, 1), interval.Minor(6)},
+			Remove: []interval.Interval{interval.Major(2), interval.Perfect(4)},
+		})
+	}
+
+	return append(modifiers, []ChordModifier{
 		{
 			Name:   "Augmented",
 			Match:  regexp.MustCompile("^" + regexOr(cfg.AugmentedSymbols)),
@@ -157,6 +263,12 @@ func BuildChordModifiers(cfg ConfigBase) []ChordModifier {
 			Add:    []interval.Interval{interval.Major(6), interval.Major(8), interval.Perfect(10), interval.Major(12)},
 			Remove: []interval.Interval{interval.Minor(6)},
 		},
+		{
+			Name:   "Altered",
+			Match:  regexp.MustCompile("^7?(?P<mod>alt)"),
+			Add:    []interval.Interval{interval.Minor(6), interval.Diminished(4, 1), interval.Augmented(4, 1), interval.Minor(8), interval.Augmented(8, 1)},
+			Remove: []interval.Interval{interval.Perfect(4)},
+		},
 		{
 			Name:   "Suspended 2nd",
 			Match:  regexp.MustCompile("sus2"),
@@ -243,7 +355,88 @@ func BuildChordModifiers(cfg ConfigBase) []ChordModifier {
 			Match: regexp.MustCompile(`\(` + regexOrWithSuffix("11", cfg.SharpSymbols) + `\)|` + regexOrWithSuffix("11", cfg.SharpSymbols)),
 			Add:   []interval.Interval{interval.Augmented(10, 1)},
 		},
+	}...)
+}
+
+func BuildExtraChordModifiers(cfg ConfigBase) ([]ChordModifier, error) {
+	modifiers := make([]ChordModifier, 0, len(cfg.ExtraChordModifiers))
+	for i, def := range cfg.ExtraChordModifiers {
+		if len(def.Name) == 0 {
+			return nil, fmt.Errorf("extraChordModifiers[%d]: name is required", i)
+		}
+		if len(def.Match) == 0 {
+			return nil, fmt.Errorf("extraChordModifiers[%d] (%q): match is required", i, def.Name)
+		}
+		if len(def.Add) == 0 && len(def.Remove) == 0 {
+			return nil, fmt.Errorf("extraChordModifiers[%d] (%q): at least one interval must be added or removed", i, def.Name)
+		}
+
+		match, err := regexp.Compile(def.Match)
+		if err != nil {
+			return nil, fmt.Errorf("extraChordModifiers[%d] (%q): invalid match expression: %w", i, def.Name, err)
+		}
+
+		var except *regexp.Regexp
+		if len(def.Except) > 0 {
+			except, err = regexp.Compile(def.Except)
+			if err != nil {
+				return nil, fmt.Errorf("extraChordModifiers[%d] (%q): invalid except expression: %w", i, def.Name, err)
+			}
+		}
+
+		modifiers = append(modifiers, ChordModifier{
+			Name:   def.Name,
+			Match:  match,
+			Except: except,
+			Add:    def.Add,
+			Remove: def.Remove,
+		})
 	}
+
+	return modifiers, nil
+}
+
+func (cfg *Config) buildChordAliases() error {
+	symbols := make([]string, 0, len(cfg.ChordAliases))
+	for symbol := range cfg.ChordAliases {
+		symbols = append(symbols, symbol)
+	}
+
+	// Longer symbols are tried first so that "m(maj7)" wins over "m".
+	sort.Slice(symbols, func(i, j int) bool {
+		if len(symbols[i]) != len(symbols[j]) {
+			return len(symbols[i]) > len(symbols[j])
+		}
+		return symbols[i] < symbols[j]
+	})
+
+	t := New(cfg)
+	for _, symbol := range symbols {
+		suffix := cfg.ChordAliases[symbol]
+		if len(strings.TrimSpace(symbol)) == 0 {
+			return fmt.Errorf("chordAliases: symbols cannot be empty")
+		}
+
+		for _, delim := range cfg.BaseNoteDelimiters {
+			if strings.Contains(symbol, delim) || strings.Contains(suffix, delim) {
+				return fmt.Errorf("chordAliases[%q]: cannot contain the base note delimiter %q", symbol, delim)
+			}
+		}
+
+		root := cfg.NaturalNoteNames[0]
+		if _, err := t.ParseChord(root + suffix); err != nil {
+			return fmt.Errorf("chordAliases[%q]: %q is not a valid chord suffix: %w", symbol, suffix, err)
+		}
+	}
+
+	for _, symbol := range symbols {
+		cfg.chordAliases = append(cfg.chordAliases, chordAlias{
+			symbol: symbol,
+			suffix: cfg.ChordAliases[symbol],
+		})
+	}
+
+	return nil
 }
 
 func regexOr(sss ...[]string) string {
@@ -0,0 +1,209 @@
+package theory_test
+
+import (
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/craiggwilson/songtool/pkg/theory/interval"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfig(t *testing.T) {
+	testCases := []struct {
+		name           string
+		aliases        map[string]string
+		modifiers      []theory.ChordModifierDefinition
+		chordStyle     string
+		configure      func(base *theory.ConfigBase)
+		expectedErrMsg string
+	}{
+		{
+			name: "valid",
+			aliases: map[string]string{
+				"Δ": "maj7",
+			},
+			modifiers: []theory.ChordModifierDefinition{
+				{
+					Name:  "Altered",
+					Match: "alt",
+					Add:   []interval.Interval{interval.Minor(8)},
+				},
+			},
+		},
+		{
+			name: "missing name",
+			modifiers: []theory.ChordModifierDefinition{
+				{
+					Match: "alt",
+					Add:   []interval.Interval{interval.Minor(8)},
+				},
+			},
+			expectedErrMsg: "extraChordModifiers[0]: name is required",
+		},
+		{
+			name: "missing match",
+			modifiers: []theory.ChordModifierDefinition{
+				{
+					Name: "Altered",
+					Add:  []interval.Interval{interval.Minor(8)},
+				},
+			},
+			expectedErrMsg: `extraChordModifiers[0] ("Altered"): match is required`,
+		},
+		{
+			name: "no intervals",
+			modifiers: []theory.ChordModifierDefinition{
+				{
+					Name:  "Altered",
+					Match: "alt",
+				},
+			},
+			expectedErrMsg: `extraChordModifiers[0] ("Altered"): at least one interval must be added or removed`,
+		},
+		{
+			name: "invalid match",
+			modifiers: []theory.ChordModifierDefinition{
+				{
+					Name:  "Altered",
+					Match: "alt(",
+					Add:   []interval.Interval{interval.Minor(8)},
+				},
+			},
+			expectedErrMsg: `extraChordModifiers[0] ("Altered"): invalid match expression: error parsing regexp: missing closing ): ` + "`alt(`",
+		},
+		{
+			name: "invalid alias",
+			aliases: map[string]string{
+				"Δ": "mja7",
+			},
+			expectedErrMsg: `chordAliases["Δ"]: "mja7" is not a valid chord suffix: expected EOF at position 2, but had ja7`,
+		},
+		{
+			name:           "unknown chord style",
+			chordStyle:     "bebop",
+			expectedErrMsg: `chordStyle: unknown chord style "bebop"`,
+		},
+		{
+			name: "alias with base note delimiter",
+			aliases: map[string]string{
+				"6/9": "69",
+			},
+			expectedErrMsg: `chordAliases["6/9"]: cannot contain the base note delimiter "/"`,
+		},
+		{
+			name: "no half diminished symbols",
+			configure: func(base *theory.ConfigBase) {
+				base.HalfDiminishedSymbols = []string{}
+			},
+			expectedErrMsg: "halfDiminishedSymbols: at least one symbol is required",
+		},
+		{
+			name: "blank minor symbol",
+			configure: func(base *theory.ConfigBase) {
+				base.MinorSymbols = []string{"m", " "}
+			},
+			expectedErrMsg: "minorSymbols[1]: symbols cannot be blank",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			base := theory.DefaultConfigBase()
+			base.ChordAliases = tc.aliases
+			base.ExtraChordModifiers = tc.modifiers
+			base.ChordStyle = tc.chordStyle
+			if tc.configure != nil {
+				tc.configure(&base)
+			}
+
+			_, err := theory.NewConfig(base)
+			if len(tc.expectedErrMsg) > 0 {
+				require.EqualError(t, err, tc.expectedErrMsg)
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestParseChord_Custom(t *testing.T) {
+	base := theory.DefaultConfigBase()
+	base.ChordAliases = map[string]string{
+		"Δ7":      "maj7",
+		"Δ":       "maj7",
+		"m(maj7)": "mmaj7",
+		"♭":       "b",
+	}
+	base.ExtraChordModifiers = []theory.ChordModifierDefinition{
+		{
+			Name:  "Lydian Dominant",
+			Match: "lyd",
+			Add:   []interval.Interval{interval.Minor(6), interval.Augmented(10, 1)},
+		},
+	}
+
+	cfg, err := theory.NewConfig(base)
+	require.Nil(t, err)
+	th := theory.New(cfg)
+
+	testCases := []struct {
+		text           string
+		expectedName   string
+		expectedSuffix string
+	}{
+		{
+			text:           "CΔ7",
+			expectedName:   "Cmaj7",
+			expectedSuffix: "Δ7",
+		},
+		{
+			text:           "CΔ/E",
+			expectedName:   "Cmaj7/E",
+			expectedSuffix: "Δ",
+		},
+		{
+			text:           "Am(maj7)",
+			expectedName:   "Ammaj7",
+			expectedSuffix: "m(maj7)",
+		},
+		{
+			text:           "G7lyd",
+			expectedName:   "G7#11",
+			expectedSuffix: "7lyd",
+		},
+		{
+			text:           "C7/B♭",
+			expectedName:   "C7/Bb",
+			expectedSuffix: "7",
+		},
+		{
+			text:           "CΔ/B♭",
+			expectedName:   "Cmaj7/Bb",
+			expectedSuffix: "Δ",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			parsed, err := th.ParseChord(tc.text)
+			require.Nil(t, err)
+			require.Equal(t, tc.text, parsed.Name)
+			require.Equal(t, tc.expectedSuffix, parsed.Suffix)
+			require.Equal(t, tc.expectedName, th.NameChord(parsed.Chord))
+		})
+	}
+}
+
+func TestBuildChordModifiers_NoHalfDiminishedSymbols(t *testing.T) {
+	base := theory.DefaultConfigBase()
+	base.HalfDiminishedSymbols = nil
+	th := theory.New(&theory.Config{
+		ConfigBase:     base,
+		ChordModifiers: theory.BuildChordModifiers(base),
+	})
+
+	parsed, err := th.ParseChord("G7")
+	require.Nil(t, err)
+	require.Equal(t, "7", parsed.Suffix)
+	require.Equal(t, "G7", th.NameChord(parsed.Chord))
+}
@@ -0,0 +1,125 @@
+package harmony
+
+import (
+	"math"
+
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
+)
+
+const (
+	// MinDifficulty and MaxDifficulty bound the difficulty of a song.
+	MinDifficulty = 1
+	MaxDifficulty = 10
+
+	// easyChordCount is the number of distinct chords a song may have before each additional one counts against it.
+	easyChordCount = 3
+	// easyChangesPerLine is the number of chords a line may have before each additional one counts against it.
+	easyChangesPerLine = 4
+)
+
+// Difficulty rates how hard the chords are to play, from MinDifficulty to MaxDifficulty. The chords are every chord
+// played, in order, and lines is the number of lines they are spread over. Points are given for each distinct chord
+// past the first few, and for each distinct chord's sevenths, extensions, alterations, slash basses, and diminished,
+// augmented, or suspended quality, as well as for the accidentals in the key signature and for many chord changes in
+// a line. The key may be nil when it is not known.
+func Difficulty(k *key.Key, chords []chord.Chord, lines int) int {
+	if len(chords) == 0 {
+		return MinDifficulty
+	}
+
+	points := 0.0
+
+	seen := make(map[string]struct{})
+	for _, c := range chords {
+		id := chordID(c)
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		points += chordDifficulty(c)
+	}
+
+	if distinct := len(seen); distinct > easyChordCount {
+		points += float64(distinct-easyChordCount) * 0.5
+	}
+
+	if k != nil {
+		points += math.Abs(float64(k.Accidentals())) * 0.5
+	}
+
+	if lines > 0 {
+		if changes := float64(len(chords)) / float64(lines); changes > easyChangesPerLine {
+			points += changes - easyChangesPerLine
+		}
+	}
+
+	difficulty := MinDifficulty + int(math.Round(points/2))
+	if difficulty > MaxDifficulty {
+		difficulty = MaxDifficulty
+	}
+
+	return difficulty
+}
+
+func chordDifficulty(c chord.Chord) float64 {
+	points := 0.0
+	if base := c.Base(); base != nil && base.PitchClass() != c.Root().PitchClass() {
+		points++
+	}
+
+	simplified := chord.Simplify(c, chord.SimplifyExtensions)
+	for _, ival := range simplified.Intervals() {
+		if ival.Diatonic() == 6 {
+			points += 0.5
+		}
+	}
+
+	kept := make(map[int]struct{}, len(simplified.Intervals()))
+	for _, ival := range simplified.Intervals() {
+		kept[ival.Diatonic()*12+ival.Chromatic()] = struct{}{}
+	}
+
+	for _, ival := range c.Intervals() {
+		if _, ok := kept[ival.Diatonic()*12+ival.Chromatic()]; ok {
+			continue
+		}
+
+		// Natural extensions are easier than alterations.
+		if isNatural(ival.Diatonic(), ival.Chromatic()) {
+			points++
+		} else {
+			points += 2
+		}
+	}
+
+	switch simplified.Quality() {
+	case chord.QualityDiminished, chord.QualityAugmented:
+		points++
+	case chord.QualityIndeterminate:
+		points += 0.5
+	}
+
+	return points
+}
+
+// isNatural indicates whether the interval is major or perfect.
+func isNatural(diatonic, chromatic int) bool {
+	return chromatic%12 == majorScaleChromatic[diatonic%7]
+}
+
+var majorScaleChromatic = [7]int{0, 2, 4, 5, 7, 9, 11}
+
+func chordID(c chord.Chord) string {
+	id := make([]byte, 0, 2+len(c.Intervals())*2)
+	id = append(id, byte(c.Root().PitchClass()))
+	if base := c.Base(); base != nil {
+		id = append(id, byte(12+base.PitchClass()))
+	}
+	for _, ival := range c.Intervals() {
+		id = append(id, byte(ival.Diatonic()), byte(ival.Chromatic()))
+	}
+
+	return string(id)
+}
@@ -0,0 +1,83 @@
+package harmony_test
+
+import (
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDifficulty(t *testing.T) {
+	testCases := []struct {
+		name     string
+		key      string
+		chords   string
+		lines    int
+		expected int
+	}{
+		{
+			name:     "no chords",
+			expected: 1,
+		},
+		{
+			name:     "three chords",
+			key:      "G",
+			chords:   "G C D G C D",
+			lines:    2,
+			expected: 1,
+		},
+		{
+			name:     "four chords with sevenths",
+			key:      "E",
+			chords:   "E A B7 C#m E A B7 E",
+			lines:    2,
+			expected: 3,
+		},
+		{
+			name:     "jazz",
+			key:      "Db",
+			chords:   "Dbmaj9 Bb7b9 Ebm9 Ab13 Dbmaj7/F Edim7 Ebm11 Ab7#5",
+			lines:    2,
+			expected: 10,
+		},
+		{
+			name:     "many changes",
+			key:      "C",
+			chords:   "C G Am F C G Am F C G Am F",
+			lines:    1,
+			expected: 5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			chords := parseChords(t, tc.chords)
+
+			if len(tc.key) == 0 {
+				require.Equal(t, tc.expected, harmony.Difficulty(nil, chords, tc.lines))
+				return
+			}
+
+			parsed, err := theory.ParseKey(tc.key)
+			require.Nil(t, err)
+			require.Equal(t, tc.expected, harmony.Difficulty(&parsed.Key, chords, tc.lines))
+		})
+	}
+}
+
+func TestDifficulty_KeySignature(t *testing.T) {
+	chords := parseChords(t, "C F G")
+
+	var last int
+	for _, name := range []string{"C", "G", "D", "A", "E", "B", "F#"} {
+		k, err := theory.ParseKey(name)
+		require.Nil(t, err)
+
+		difficulty := harmony.Difficulty(&k.Key, chords, 1)
+		require.GreaterOrEqual(t, difficulty, last, name)
+		last = difficulty
+	}
+
+	require.Greater(t, last, harmony.MinDifficulty)
+}
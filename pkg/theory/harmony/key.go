@@ -0,0 +1,99 @@
+package harmony
+
+import (
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
+)
+
+var (
+	// majorDegrees maps the half steps above the tonic of a major key to the quality of the diatonic triad.
+	majorDegrees = map[int][]chord.Quality{
+		0:  {chord.QualityMajor},
+		2:  {chord.QualityMinor},
+		4:  {chord.QualityMinor},
+		5:  {chord.QualityMajor},
+		7:  {chord.QualityMajor},
+		9:  {chord.QualityMinor},
+		11: {chord.QualityDiminished},
+	}
+
+	// minorDegrees maps the half steps above the tonic of a minor key to the quality of the diatonic triad. The
+	// dominant may be major or minor to account for the harmonic minor.
+	minorDegrees = map[int][]chord.Quality{
+		0:  {chord.QualityMinor},
+		2:  {chord.QualityDiminished},
+		3:  {chord.QualityMajor},
+		5:  {chord.QualityMinor},
+		7:  {chord.QualityMinor, chord.QualityMajor},
+		8:  {chord.QualityMajor},
+		10: {chord.QualityMajor},
+	}
+)
+
+// DetectKey infers the key of a sequence of chords. The tonic is chosen from the roots of the chords, so the key is
+// spelled the same way as the chords. It returns false when there are no chords.
+func DetectKey(chords []chord.Chord) (key.Key, bool) {
+	var best key.Key
+	bestScore := -1
+	seen := make(map[int]struct{})
+	for _, c := range chords {
+		root := c.Root()
+		if _, ok := seen[root.PitchClass()]; ok {
+			continue
+		}
+		seen[root.PitchClass()] = struct{}{}
+
+		for _, k := range []key.Key{key.Major(root), key.Minor(root)} {
+			if score := ScoreKey(k, chords); score > bestScore {
+				best = k
+				bestScore = score
+			}
+		}
+	}
+
+	return best, bestScore >= 0
+}
+
+// ScoreKey rates how well the chords fit in the key. Each chord whose root is in the key scores a point, and another
+// point when its quality matches the diatonic triad. Starting or ending on the tonic chord scores two more points
+// each.
+func ScoreKey(k key.Key, chords []chord.Chord) int {
+	if len(chords) == 0 {
+		return 0
+	}
+
+	degrees := degreesFor(k)
+
+	score := 0
+	for _, c := range chords {
+		qualities, ok := degrees[(c.Root().PitchClass()-k.Note().PitchClass()+12)%12]
+		if !ok {
+			continue
+		}
+
+		score++
+		for _, q := range qualities {
+			if c.Quality() == q {
+				score++
+				break
+			}
+		}
+	}
+
+	if isTonic(k, chords[0]) {
+		score += 2
+	}
+	if isTonic(k, chords[len(chords)-1]) {
+		score += 2
+	}
+
+	return score
+}
+
+func degreesFor(k key.Key) map[int][]chord.Quality {
+	if k.Kind() == key.KindMinor {
+		return minorDegrees
+	}
+
+	return majorDegrees
+}
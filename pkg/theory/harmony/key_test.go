@@ -0,0 +1,112 @@
+package harmony_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectKey(t *testing.T) {
+	testCases := []struct {
+		chords   string
+		expected string
+	}{
+		{
+			chords:   "G G7 C G D G Em C D G",
+			expected: "G",
+		},
+		{
+			chords:   "Am F C G Am",
+			expected: "Am",
+		},
+		{
+			chords:   "C Am F G",
+			expected: "C",
+		},
+		{
+			chords:   "Dm Bb Gm A7 Dm",
+			expected: "Dm",
+		},
+		{
+			chords:   "Eb Ab Bb7 Eb",
+			expected: "Eb",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.chords, func(t *testing.T) {
+			actual, ok := harmony.DetectKey(parseChords(t, tc.chords))
+			require.True(t, ok)
+			require.Equal(t, tc.expected, theory.NameKey(actual))
+		})
+	}
+}
+
+func TestDetectKey_NoChords(t *testing.T) {
+	_, ok := harmony.DetectKey(nil)
+	require.False(t, ok)
+}
+
+func parseChords(t *testing.T, text string) []chord.Chord {
+	var chords []chord.Chord
+	for _, name := range strings.Fields(text) {
+		c, err := theory.ParseChord(name)
+		require.Nil(t, err)
+		chords = append(chords, c.Chord)
+	}
+
+	return chords
+}
+
+func TestDetectModulations(t *testing.T) {
+	testCases := []struct {
+		name     string
+		start    string
+		chords   string
+		expected []string
+	}{
+		{
+			name:   "no modulation",
+			start:  "G",
+			chords: "G C D G Em C D G G C D G",
+		},
+		{
+			name:     "half step lift",
+			start:    "G",
+			chords:   "G C D G Em C D G Ab Db Eb Ab Fm Db Eb Ab",
+			expected: []string{"8:Ab"},
+		},
+		{
+			name:     "whole step lift",
+			start:    "C",
+			chords:   "C F G C Am F G C C F G C Am F G C D G A D Bm G A D D G A D Bm G A D",
+			expected: []string{"16:D"},
+		},
+		{
+			name:     "relative minor bridge",
+			start:    "G",
+			chords:   "G C D G C D G G Em Am B7 Em Am B7 Em Em G C D G C D G G",
+			expected: []string{"8:Em", "16:G"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start, err := theory.ParseKey(tc.start)
+			require.Nil(t, err)
+
+			modulations := harmony.DetectModulations(start.Key, parseChords(t, tc.chords), harmony.DefaultWindow)
+
+			var actual []string
+			for _, m := range modulations {
+				actual = append(actual, fmt.Sprintf("%d:%s", m.Index, theory.NameKey(m.Key)))
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package harmony
+
+import (
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
+)
+
+// DefaultWindow is the number of chords examined at a time when detecting modulations.
+const DefaultWindow = 8
+
+// modulationMargin is how much better a new key must fit the chords in a window than the current key.
+const modulationMargin = 4
+
+// Modulation is a change of key starting at the chord with the index.
+type Modulation struct {
+	Index int
+	Key   key.Key
+}
+
+// DetectModulations slides a window over the chords, starting in the key, and reports where the chords begin to fit
+// a different key considerably better than the current one. The change is placed at the first chord in the window
+// that is either the new tonic or does not belong to the current key. The new key must still fit the chords that follow
+// the change best, and considerably better than the current key, and must hold past any chords it shares with the
+// current key.
+func DetectModulations(start key.Key, chords []chord.Chord, window int) []Modulation {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+
+	if len(chords) < window {
+		return nil
+	}
+
+	var modulations []Modulation
+	current := start
+	for i := 0; i+window <= len(chords); i++ {
+		w := chords[i : i+window]
+		candidate, ok := DetectKey(w)
+		if !ok || sameKey(candidate, current) {
+			continue
+		}
+
+		if ScoreKey(candidate, w)-ScoreKey(current, w) < modulationMargin {
+			continue
+		}
+
+		j := i
+		for ; j < i+window; j++ {
+			if isTonic(candidate, chords[j]) || !fits(current, chords[j]) {
+				break
+			}
+		}
+
+		if len(modulations) > 0 && j <= modulations[len(modulations)-1].Index {
+			continue
+		}
+
+		// The window that found the change straddles it, so the new key is taken from the chords after the change.
+		if end := j + window; end <= len(chords) {
+			after := chords[j:end]
+			if candidate, ok = DetectKey(after); !ok || sameKey(candidate, current) {
+				continue
+			}
+
+			if ScoreKey(candidate, after)-ScoreKey(current, after) < modulationMargin {
+				continue
+			}
+		}
+
+		if !holds(current, candidate, chords, j, window) {
+			continue
+		}
+
+		modulations = append(modulations, Modulation{
+			Index: j,
+			Key:   candidate,
+		})
+		current = candidate
+		i = j
+	}
+
+	return modulations
+}
+
+// holds indicates whether the new key still fits best over the window that starts at the first chord after the change
+// that is outside the current key. Chords at the change that fit both keys may otherwise be taken for a key that is
+// only passed through.
+func holds(current, candidate key.Key, chords []chord.Chord, start, window int) bool {
+	for start < len(chords) && fits(current, chords[start]) {
+		start++
+	}
+
+	end := start + window
+	if end > len(chords) {
+		end = len(chords)
+	}
+
+	w := chords[start:end]
+	if len(w) == 0 {
+		return true
+	}
+
+	detected, ok := DetectKey(w)
+	return ok && sameKey(detected, candidate)
+}
+
+// fits indicates whether the chord is diatonic to the key, with the expected quality.
+func fits(k key.Key, c chord.Chord) bool {
+	qualities, ok := degreesFor(k)[(c.Root().PitchClass()-k.Note().PitchClass()+12)%12]
+	if !ok {
+		return false
+	}
+
+	for _, q := range qualities {
+		if c.Quality() == q {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isTonic(k key.Key, c chord.Chord) bool {
+	tonicQuality := chord.QualityMajor
+	if k.Kind() == key.KindMinor {
+		tonicQuality = chord.QualityMinor
+	}
+
+	return c.Root().PitchClass() == k.Note().PitchClass() && c.Quality() == tonicQuality
+}
+
+func sameKey(a, b key.Key) bool {
+	return a.Kind() == b.Kind() && a.Note().PitchClass() == b.Note().PitchClass()
+}
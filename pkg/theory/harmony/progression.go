@@ -0,0 +1,263 @@
+package harmony
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/interval"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
+)
+
+// DefaultProgressions returns the built-in progressions, keyed by name and written as Roman numerals.
+func DefaultProgressions() map[string]string {
+	return map[string]string{
+		"I–V–vi–IV":          "I V vi IV",
+		"I–vi–IV–V":          "I vi IV V",
+		"ii–V–I":             "ii V I",
+		"12-Bar Blues":       "I7 I7 I7 I7 IV7 IV7 I7 I7 V7 IV7 I7 I7",
+		"Andalusian Cadence": "i bVII bVI V",
+		"Circle":             "vi ii V I",
+	}
+}
+
+// romanDegrees are the half steps above the tonic of the major scale degree for each Roman numeral.
+var romanDegrees = map[string]int{
+	"I":   0,
+	"II":  2,
+	"III": 4,
+	"IV":  5,
+	"V":   7,
+	"VI":  9,
+	"VII": 11,
+}
+
+// Numeral is a chord written as a Roman numeral relative to the tonic of a key.
+type Numeral struct {
+	Text string
+	// Degree is the number of half steps the root of the chord is above the tonic.
+	Degree  int
+	Quality chord.Quality
+}
+
+// ParseNumeral parses a Roman numeral such as "IV", "vi", "bVII", "vii°", or "V7". Upper case numerals are major and
+// lower case numerals are minor, unless followed by "°" or "ø" for diminished or "+" for augmented. Any extensions,
+// such as the 7 in "V7", are allowed but ignored when matching.
+func ParseNumeral(text string) (Numeral, error) {
+	rest := text
+	offset := 0
+	for len(rest) > 0 {
+		switch {
+		case strings.HasPrefix(rest, "b"):
+			offset--
+			rest = rest[1:]
+			continue
+		case strings.HasPrefix(rest, "#"):
+			offset++
+			rest = rest[1:]
+			continue
+		case strings.HasPrefix(rest, "♭"):
+			offset--
+			rest = rest[len("♭"):]
+			continue
+		case strings.HasPrefix(rest, "♯"):
+			offset++
+			rest = rest[len("♯"):]
+			continue
+		}
+		break
+	}
+
+	end := strings.IndexFunc(rest, func(r rune) bool {
+		return !strings.ContainsRune("IViv", r)
+	})
+	if end < 0 {
+		end = len(rest)
+	}
+
+	roman := rest[:end]
+	degree, ok := romanDegrees[strings.ToUpper(roman)]
+	if !ok {
+		return Numeral{}, fmt.Errorf("%q is not a roman numeral", text)
+	}
+
+	var quality chord.Quality
+	switch roman {
+	case strings.ToUpper(roman):
+		quality = chord.QualityMajor
+	case strings.ToLower(roman):
+		quality = chord.QualityMinor
+	default:
+		return Numeral{}, fmt.Errorf("%q mixes upper and lower case", text)
+	}
+
+	rest = rest[end:]
+	switch {
+	case strings.HasPrefix(rest, "°"), strings.HasPrefix(rest, "o"), strings.HasPrefix(rest, "ø"):
+		quality = chord.QualityDiminished
+		rest = strings.TrimLeft(rest, "°oø")
+	case strings.HasPrefix(rest, "+"):
+		quality = chord.QualityAugmented
+		rest = rest[1:]
+	}
+
+	if strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsDigit(r) }) >= 0 {
+		return Numeral{}, fmt.Errorf("%q has an unknown suffix %q", text, rest)
+	}
+
+	return Numeral{
+		Text:    text,
+		Degree:  ((degree+offset)%12 + 12) % 12,
+		Quality: quality,
+	}, nil
+}
+
+// Progression is a named sequence of chords written as Roman numerals.
+type Progression struct {
+	Name     string
+	Numerals []Numeral
+}
+
+// ParseProgression parses the Roman numerals in the text, separated by spaces or dashes.
+func ParseProgression(name, text string) (Progression, error) {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-' || r == '–' || r == '—'
+	})
+	if len(fields) == 0 {
+		return Progression{}, fmt.Errorf("progression %q has no chords", name)
+	}
+
+	p := Progression{Name: name}
+	for _, field := range fields {
+		n, err := ParseNumeral(field)
+		if err != nil {
+			return Progression{}, fmt.Errorf("progression %q: %w", name, err)
+		}
+		p.Numerals = append(p.Numerals, n)
+	}
+
+	return p, nil
+}
+
+// ParseProgressions parses each of the progressions, keyed by name, and returns them ordered by name.
+func ParseProgressions(progressions map[string]string) ([]Progression, error) {
+	names := make([]string, 0, len(progressions))
+	for name := range progressions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]Progression, 0, len(names))
+	for _, name := range names {
+		p, err := ParseProgression(name, progressions[name])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+
+	return result, nil
+}
+
+// IsMinor indicates whether the progression is written relative to a minor tonic, which is when it has a minor
+// tonic chord.
+func (p Progression) IsMinor() bool {
+	for _, n := range p.Numerals {
+		if n.Degree == 0 && n.Quality == chord.QualityMinor {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ProgressionMatch is an occurrence of a progression in a sequence of chords, covering Count chords starting at Index.
+// Key is the key the progression was matched in, which is the relative minor or major of the key the chords were
+// searched in when the progression is written in the other kind of key.
+type ProgressionMatch struct {
+	Progression Progression
+	Key         key.Key
+	Index       int
+	Count       int
+}
+
+// FindProgressions finds the occurrences of the progressions in the chords in the key. A minor progression is matched
+// relative to the relative minor of a major key, and a major progression relative to the relative major of a minor
+// key. Repeated chords are treated as a single chord, both in the chords and in the progressions, unless the
+// progression repeats a numeral itself, such as the 12-bar blues, in which case the chords must match it chord for
+// chord. A chord without a clear quality matches any numeral on its root. The matches are ordered by where they start.
+func FindProgressions(k key.Key, chords []chord.Chord, progressions []Progression) []ProgressionMatch {
+	type step struct {
+		degree  int
+		quality chord.Quality
+		start   int
+		end     int
+	}
+
+	collapse := func(tonic int, merge bool) []step {
+		var steps []step
+		for i, c := range chords {
+			degree := (c.Root().PitchClass() - tonic + 12) % 12
+			if n := len(steps); merge && n > 0 && steps[n-1].degree == degree && steps[n-1].quality == c.Quality() {
+				steps[n-1].end = i
+				continue
+			}
+			steps = append(steps, step{degree, c.Quality(), i, i})
+		}
+		return steps
+	}
+
+	var matches []ProgressionMatch
+	for _, p := range progressions {
+		pKey := k
+		switch {
+		case p.IsMinor() && k.Kind() != key.KindMinor:
+			pKey = key.Minor(k.Note().Transpose(interval.Major(5)))
+		case !p.IsMinor() && k.Kind() == key.KindMinor:
+			pKey = key.Major(k.Note().Transpose(interval.Minor(2)))
+		}
+
+		// The length of each chord matters to a progression that repeats a numeral, so neither is collapsed.
+		numerals := p.Numerals
+		repeats := false
+		for i := 1; i < len(numerals); i++ {
+			if numerals[i].Degree == numerals[i-1].Degree && numerals[i].Quality == numerals[i-1].Quality {
+				repeats = true
+				break
+			}
+		}
+
+		steps := collapse(pKey.Note().PitchClass(), !repeats)
+		for i := 0; i+len(numerals) <= len(steps); i++ {
+			matched := true
+			for j, n := range numerals {
+				s := steps[i+j]
+				if s.degree != n.Degree || (s.quality != n.Quality && s.quality != chord.QualityIndeterminate) {
+					matched = false
+					break
+				}
+			}
+
+			if !matched {
+				continue
+			}
+
+			last := steps[i+len(numerals)-1]
+			matches = append(matches, ProgressionMatch{
+				Progression: p,
+				Key:         pKey,
+				Index:       steps[i].start,
+				Count:       last.end - steps[i].start + 1,
+			})
+			i += len(numerals) - 1
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Index < matches[j].Index
+	})
+
+	return matches
+}
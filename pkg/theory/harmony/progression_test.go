@@ -0,0 +1,130 @@
+package harmony_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNumeral(t *testing.T) {
+	testCases := []struct {
+		numeral         string
+		expectedDegree  int
+		expectedQuality chord.Quality
+		expectedErr     bool
+	}{
+		{numeral: "I", expectedDegree: 0, expectedQuality: chord.QualityMajor},
+		{numeral: "vi", expectedDegree: 9, expectedQuality: chord.QualityMinor},
+		{numeral: "bVII", expectedDegree: 10, expectedQuality: chord.QualityMajor},
+		{numeral: "#iv°", expectedDegree: 6, expectedQuality: chord.QualityDiminished},
+		{numeral: "V7", expectedDegree: 7, expectedQuality: chord.QualityMajor},
+		{numeral: "III+", expectedDegree: 4, expectedQuality: chord.QualityAugmented},
+		{numeral: "viiø7", expectedDegree: 11, expectedQuality: chord.QualityDiminished},
+		{numeral: "Iv", expectedErr: true},
+		{numeral: "X", expectedErr: true},
+		{numeral: "Vsus", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.numeral, func(t *testing.T) {
+			actual, err := harmony.ParseNumeral(tc.numeral)
+			if tc.expectedErr {
+				require.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.expectedDegree, actual.Degree)
+			require.Equal(t, tc.expectedQuality, actual.Quality)
+		})
+	}
+}
+
+func TestFindProgressions(t *testing.T) {
+	progressions, err := harmony.ParseProgressions(harmony.DefaultProgressions())
+	require.Nil(t, err)
+
+	testCases := []struct {
+		key      string
+		chords   string
+		expected []string
+	}{
+		{
+			key:      "G",
+			chords:   "G D Em C G D Em C",
+			expected: []string{"0:4:I–V–vi–IV in G", "4:4:I–V–vi–IV in G"},
+		},
+		{
+			key:      "C",
+			chords:   "C Am F G7 Dm7 G7 C",
+			expected: []string{"0:4:I–vi–IV–V in C", "4:3:ii–V–I in C"},
+		},
+		{
+			key:      "C",
+			chords:   "Am Dm G C",
+			expected: []string{"0:4:Circle in C", "1:3:ii–V–I in C"},
+		},
+		{
+			key:      "E",
+			chords:   "E7 E7 E7 E7 A7 A7 E7 E7 B7 A7 E7 E7",
+			expected: []string{"0:12:12-Bar Blues in E"},
+		},
+		{
+			key:    "E",
+			chords: "E7 A7 E7 B7 A7 E7",
+		},
+		{
+			key:    "E",
+			chords: "E7 E7 A7 A7 E7 E7 B7 A7 E7 E7",
+		},
+		{
+			key:      "Am",
+			chords:   "Am G F E",
+			expected: []string{"0:4:Andalusian Cadence in Am"},
+		},
+		{
+			key:      "C",
+			chords:   "Am G F E",
+			expected: []string{"0:4:Andalusian Cadence in Am"},
+		},
+		{
+			key:      "Eb",
+			chords:   "Cm Bb Ab G",
+			expected: []string{"0:4:Andalusian Cadence in Cm"},
+		},
+		{
+			key:      "Am",
+			chords:   "C Am F G",
+			expected: []string{"0:4:I–vi–IV–V in C"},
+		},
+		{
+			key:    "C",
+			chords: "C F C F",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.chords, func(t *testing.T) {
+			k, err := theory.ParseKey(tc.key)
+			require.Nil(t, err)
+
+			var actual []string
+			for _, m := range harmony.FindProgressions(k.Key, parseChords(t, tc.chords), progressions) {
+				actual = append(actual, fmt.Sprintf("%d:%d:%s in %s", m.Index, m.Count, m.Progression.Name, theory.NameKey(m.Key)))
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestParseProgression_Errors(t *testing.T) {
+	_, err := harmony.ParseProgression("Empty", " - ")
+	require.NotNil(t, err)
+
+	_, err = harmony.ParseProgression("Bad", "I V Z")
+	require.NotNil(t, err)
+}
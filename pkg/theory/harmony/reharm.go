@@ -0,0 +1,180 @@
+package harmony
+
+import (
+	"fmt"
+
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/interval"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
+	"github.com/craiggwilson/songtool/pkg/theory/note"
+)
+
+// Rule is a way of substituting one chord for another.
+type Rule string
+
+const (
+	// RuleTritone replaces a dominant chord with the dominant chord a tritone away.
+	RuleTritone Rule = "tritone"
+	// RuleRelative replaces a major chord with its relative minor, or a minor chord with its relative major.
+	RuleRelative Rule = "relative"
+	// RuleSecondaryDominant plays the dominant of a chord before it.
+	RuleSecondaryDominant Rule = "secondary-dominant"
+	// RulePassingDiminished plays a diminished chord between two chords whose roots are a whole step apart.
+	RulePassingDiminished Rule = "passing-diminished"
+	// RuleModalInterchange replaces a chord with the chord on the same degree of the parallel key.
+	RuleModalInterchange Rule = "modal-interchange"
+)
+
+// Rules are all the substitution rules, in the order they are applied.
+var Rules = []Rule{
+	RuleTritone,
+	RuleRelative,
+	RuleSecondaryDominant,
+	RulePassingDiminished,
+	RuleModalInterchange,
+}
+
+// ParseRule parses the name of a rule.
+func ParseRule(text string) (Rule, error) {
+	for _, r := range Rules {
+		if string(r) == text {
+			return r, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown rule %q", text)
+}
+
+var (
+	majorTriad        = []interval.Interval{interval.Perfect(0), interval.Major(2), interval.Perfect(4)}
+	minorTriad        = []interval.Interval{interval.Perfect(0), interval.Minor(2), interval.Perfect(4)}
+	dominantSeventh   = []interval.Interval{interval.Perfect(0), interval.Major(2), interval.Perfect(4), interval.Minor(6)}
+	diminishedSeventh = []interval.Interval{
+		interval.Perfect(0), interval.Minor(2), interval.Diminished(4, 1), interval.Diminished(6, 1),
+	}
+	halfDiminishedSeventh = []interval.Interval{
+		interval.Perfect(0), interval.Minor(2), interval.Diminished(4, 1), interval.Minor(6),
+	}
+)
+
+// Substitution is a chord proposed in place of, or before, another chord.
+type Substitution struct {
+	Rule  Rule
+	Chord chord.Chord
+	// Before indicates the chord is played before the original chord rather than instead of it.
+	Before bool
+}
+
+// Reharmonize proposes substitutions for each of the chords in the key, returning the substitutions for the chord at
+// each index in the order of the rules.
+func Reharmonize(k key.Key, chords []chord.Chord) [][]Substitution {
+	tonic := k.Note()
+	minor := k.Kind() == key.KindMinor
+
+	result := make([][]Substitution, len(chords))
+	for i, c := range chords {
+		var subs []Substitution
+		root := c.Root()
+		degree := (root.PitchClass() - tonic.PitchClass() + 12) % 12
+
+		if isDominant(c) {
+			subs = append(subs, Substitution{
+				Rule:  RuleTritone,
+				Chord: c.Transpose(interval.Diminished(4, 1)),
+			})
+		}
+
+		switch c.Quality() {
+		case chord.QualityMajor:
+			subs = append(subs, Substitution{
+				Rule:  RuleRelative,
+				Chord: chord.New(root.Transpose(interval.Major(5)), nil, minorTriad...),
+			})
+		case chord.QualityMinor:
+			subs = append(subs, Substitution{
+				Rule:  RuleRelative,
+				Chord: chord.New(root.Transpose(interval.Minor(2)), nil, majorTriad...),
+			})
+		}
+
+		if degree != 0 && fits(k, c) && c.Quality() != chord.QualityDiminished {
+			dominant := root.Transpose(interval.Perfect(4))
+			if i == 0 || !(isDominant(chords[i-1]) && chords[i-1].Root().PitchClass() == dominant.PitchClass()) {
+				subs = append(subs, Substitution{
+					Rule:   RuleSecondaryDominant,
+					Chord:  chord.New(dominant, nil, dominantSeventh...),
+					Before: true,
+				})
+			}
+		}
+
+		if i > 0 {
+			prev := chords[i-1].Root()
+			if (root.PitchClass()-prev.PitchClass()+12)%12 == 2 {
+				subs = append(subs, Substitution{
+					Rule:   RulePassingDiminished,
+					Chord:  chord.New(prev.Transpose(interval.Augmented(0, 1)), nil, diminishedSeventh...),
+					Before: true,
+				})
+			}
+		}
+
+		if borrowed, ok := borrowChord(tonic, minor, degree, c); ok {
+			subs = append(subs, Substitution{
+				Rule:  RuleModalInterchange,
+				Chord: borrowed,
+			})
+		}
+
+		result[i] = subs
+	}
+
+	return result
+}
+
+// borrowChord returns the chord on the degree of the parallel key, for the degrees that are commonly borrowed.
+func borrowChord(tonic note.Note, minor bool, degree int, c chord.Chord) (chord.Chord, bool) {
+	if minor {
+		switch {
+		case degree == 0 && c.Quality() == chord.QualityMinor:
+			return chord.New(tonic, nil, majorTriad...), true
+		case degree == 5 && c.Quality() == chord.QualityMinor:
+			return chord.New(tonic.Transpose(interval.Perfect(3)), nil, majorTriad...), true
+		case degree == 7 && c.Quality() == chord.QualityMinor:
+			return chord.New(tonic.Transpose(interval.Perfect(4)), nil, dominantSeventh...), true
+		}
+
+		return chord.Chord{}, false
+	}
+
+	switch {
+	case degree == 2 && c.Quality() == chord.QualityMinor:
+		return chord.New(tonic.Transpose(interval.Major(1)), nil, halfDiminishedSeventh...), true
+	case degree == 4 && c.Quality() == chord.QualityMinor:
+		return chord.New(tonic.Transpose(interval.Minor(2)), nil, majorTriad...), true
+	case degree == 5 && c.Quality() == chord.QualityMajor:
+		return chord.New(tonic.Transpose(interval.Perfect(3)), nil, minorTriad...), true
+	case degree == 7 && c.Quality() == chord.QualityMajor:
+		return chord.New(tonic.Transpose(interval.Minor(6)), nil, majorTriad...), true
+	case degree == 9 && c.Quality() == chord.QualityMinor:
+		return chord.New(tonic.Transpose(interval.Minor(5)), nil, majorTriad...), true
+	}
+
+	return chord.Chord{}, false
+}
+
+// isDominant indicates whether the chord is a dominant seventh chord, having a major third and a minor seventh.
+func isDominant(c chord.Chord) bool {
+	major3rd := false
+	minor7th := false
+	for _, ival := range c.Intervals() {
+		switch {
+		case ival.Diatonic() == 2 && ival.Chromatic() == 4:
+			major3rd = true
+		case ival.Diatonic() == 6 && ival.Chromatic() == 10:
+			minor7th = true
+		}
+	}
+
+	return major3rd && minor7th
+}
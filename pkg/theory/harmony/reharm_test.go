@@ -0,0 +1,80 @@
+package harmony_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReharmonize(t *testing.T) {
+	testCases := []struct {
+		key      string
+		chords   string
+		expected [][]string
+	}{
+		{
+			key:    "C",
+			chords: "C Dm G7 C",
+			expected: [][]string{
+				{"relative:Am"},
+				{"relative:F", "secondary-dominant:A7 before", "passing-diminished:C#dim7 before", "modal-interchange:Dm7b5"},
+				{"tritone:Db7", "relative:Em", "secondary-dominant:D7 before", "modal-interchange:Bb"},
+				{"relative:Am"},
+			},
+		},
+		{
+			key:    "C",
+			chords: "F G Am",
+			expected: [][]string{
+				{"relative:Dm", "secondary-dominant:C7 before", "modal-interchange:Fm"},
+				{"relative:Em", "secondary-dominant:D7 before", "passing-diminished:F#dim7 before", "modal-interchange:Bb"},
+				{"relative:C", "secondary-dominant:E7 before", "passing-diminished:G#dim7 before", "modal-interchange:Ab"},
+			},
+		},
+		{
+			key:    "Am",
+			chords: "Am Dm E7 Am",
+			expected: [][]string{
+				{"relative:C", "modal-interchange:A"},
+				{"relative:F", "secondary-dominant:A7 before", "modal-interchange:D"},
+				{"tritone:Bb7", "relative:C#m", "secondary-dominant:B7 before", "passing-diminished:D#dim7 before"},
+				{"relative:C", "modal-interchange:A"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.chords, func(t *testing.T) {
+			k, err := theory.ParseKey(tc.key)
+			require.Nil(t, err)
+
+			var actual [][]string
+			for _, subs := range harmony.Reharmonize(k.Key, parseChords(t, tc.chords)) {
+				var names []string
+				for _, s := range subs {
+					name := fmt.Sprintf("%s:%s", s.Rule, theory.NameChord(s.Chord))
+					if s.Before {
+						name += " before"
+					}
+					names = append(names, name)
+				}
+				actual = append(actual, names)
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestParseRule(t *testing.T) {
+	for _, r := range harmony.Rules {
+		actual, err := harmony.ParseRule(string(r))
+		require.Nil(t, err)
+		require.Equal(t, r, actual)
+	}
+
+	_, err := harmony.ParseRule("unknown")
+	require.NotNil(t, err)
+}
@@ -0,0 +1,175 @@
+package harmony
+
+import (
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/interval"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
+)
+
+// Relationship is how closely two keys are related.
+type Relationship string
+
+const (
+	RelationshipSame        Relationship = "same"
+	RelationshipRelative    Relationship = "relative"
+	RelationshipParallel    Relationship = "parallel"
+	RelationshipDominant    Relationship = "dominant"
+	RelationshipSubdominant Relationship = "subdominant"
+	RelationshipDistant     Relationship = "distant"
+)
+
+// maxRetune is the most half steps a song is moved when suggesting a closer key.
+const maxRetune = 2
+
+// Transition is a plan for moving from one key to the next.
+type Transition struct {
+	From key.Key
+	To   key.Key
+	// Interval is the interval from the tonic of one key to the tonic of the other.
+	Interval interval.Interval
+	// Steps is the shortest distance from one tonic to the other, in half steps, from -5 to 6.
+	Steps        int
+	Relationship Relationship
+	// Pivots are the chords in the first key that also belong to the second, in the order of the first key's degrees.
+	Pivots []chord.Chord
+	// Dominant is the dominant seventh chord of the second key.
+	Dominant chord.Chord
+	// Alternatives are keys within a whole step of the second key that are closely related to the first, nearest
+	// first. They are only suggested when the keys are distant.
+	Alternatives []key.Key
+}
+
+// PlanTransition plans the segue from one key to another.
+func PlanTransition(from, to key.Key) Transition {
+	t := Transition{
+		From:         from,
+		To:           to,
+		Interval:     from.Note().Interval(to.Note()),
+		Steps:        halfSteps(from.Note().PitchClass(), to.Note().PitchClass()),
+		Relationship: Relate(from, to),
+		Dominant:     chord.New(to.Note().Transpose(interval.Perfect(4)), nil, dominantSeventh...),
+	}
+
+	for _, c := range diatonicTriads(from) {
+		if c.Quality() != chord.QualityDiminished && fits(to, c) {
+			t.Pivots = append(t.Pivots, c)
+		}
+	}
+
+	if t.Relationship == RelationshipDistant {
+		for shift := 1; shift <= maxRetune; shift++ {
+			for _, by := range []int{shift, -shift} {
+				alt := simplestKey((to.Note().PitchClass()+by+12)%12, to.Kind())
+				if Relate(from, alt) != RelationshipDistant {
+					t.Alternatives = append(t.Alternatives, alt)
+				}
+			}
+		}
+	}
+
+	return t
+}
+
+// Relate reports how the second key is related to the first. Keys a fifth apart on the circle of fifths, counting
+// minor keys by their relative major, are dominant or subdominant.
+func Relate(from, to key.Key) Relationship {
+	switch {
+	case sameKey(from, to):
+		return RelationshipSame
+	case from.Note().PitchClass() == to.Note().PitchClass():
+		return RelationshipParallel
+	}
+
+	fromMajor, toMajor := relativeMajor(from), relativeMajor(to)
+	switch (toMajor - fromMajor + 12) % 12 {
+	case 0:
+		return RelationshipRelative
+	case 7:
+		return RelationshipDominant
+	case 5:
+		return RelationshipSubdominant
+	default:
+		return RelationshipDistant
+	}
+}
+
+// diatonicTriads returns the triads on each degree of the key, starting with the tonic. Minor keys use the natural
+// minor scale.
+func diatonicTriads(k key.Key) []chord.Chord {
+	tonic := k.Note()
+	start := 0
+	if k.Kind() == key.KindMinor {
+		tonic = tonic.Transpose(interval.Minor(2))
+		start = 5
+	}
+
+	scale := interval.Scales.Ionian
+	triads := make([]chord.Chord, 0, len(scale))
+	for i := range scale {
+		degree := (start + i) % len(scale)
+		root := tonic.Transpose(scale[degree])
+		third := root.Interval(tonic.Transpose(scale[(degree+2)%len(scale)]))
+		fifth := root.Interval(tonic.Transpose(scale[(degree+4)%len(scale)]))
+		triads = append(triads, chord.New(root, nil, interval.Perfect(0), third, fifth))
+	}
+
+	return triads
+}
+
+// halfSteps returns the shortest distance from one pitch class to another, in half steps, from -5 to 6.
+func halfSteps(from, to int) int {
+	steps := ((to-from)%12 + 12) % 12
+	if steps > 6 {
+		steps -= 12
+	}
+
+	return steps
+}
+
+func relativeMajor(k key.Key) int {
+	if k.Kind() == key.KindMinor {
+		return (k.Note().PitchClass() + 3) % 12
+	}
+
+	return k.Note().PitchClass()
+}
+
+// simplestKey returns the key of the kind on the pitch class with the fewest accidentals in its key signature. Of the
+// enharmonic spellings with as many, it prefers the tonic with the fewest accidentals, sharp in a key with sharps and
+// flat in a key with flats, so Ab is preferred over G#.
+func simplestKey(pitchClass int, kind key.Kind) key.Key {
+	var best key.Key
+	found := false
+	for _, k := range key.List() {
+		if k.Kind() != kind || k.Note().PitchClass() != pitchClass {
+			continue
+		}
+
+		if !found || abs(k.Accidentals()) < abs(best.Accidentals()) ||
+			(abs(k.Accidentals()) == abs(best.Accidentals()) && tonicSpelling(k) < tonicSpelling(best)) {
+			best = k
+			found = true
+		}
+	}
+
+	return best
+}
+
+// tonicSpelling ranks how plainly the tonic of the key is spelled: the number of accidentals on it, plus one when they
+// go against those in the key signature.
+func tonicSpelling(k key.Key) int {
+	accidentals := k.Note().Accidentals()
+	rank := abs(accidentals)
+	if accidentals*k.Accidentals() < 0 {
+		rank++
+	}
+
+	return rank
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,97 @@
+package harmony_test
+
+import (
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/craiggwilson/songtool/pkg/theory/harmony"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanTransition(t *testing.T) {
+	testCases := []struct {
+		from         string
+		to           string
+		steps        int
+		relationship harmony.Relationship
+		pivots       []string
+		dominant     string
+		alternatives []string
+	}{
+		{
+			from:         "C",
+			to:           "G",
+			steps:        -5,
+			relationship: harmony.RelationshipDominant,
+			pivots:       []string{"C", "Em", "G", "Am"},
+			dominant:     "D7",
+		},
+		{
+			from:         "C",
+			to:           "Am",
+			steps:        -3,
+			relationship: harmony.RelationshipRelative,
+			pivots:       []string{"C", "Dm", "Em", "F", "G", "Am"},
+			dominant:     "E7",
+		},
+		{
+			from:         "Am",
+			to:           "Dm",
+			steps:        5,
+			relationship: harmony.RelationshipSubdominant,
+			pivots:       []string{"Am", "C", "Dm", "F"},
+			dominant:     "A7",
+		},
+		{
+			from:         "G",
+			to:           "Bb",
+			steps:        3,
+			relationship: harmony.RelationshipDistant,
+			dominant:     "F7",
+			alternatives: []string{"C"},
+		},
+		{
+			from:         "A",
+			to:           "C",
+			steps:        3,
+			relationship: harmony.RelationshipDistant,
+			dominant:     "G7",
+			alternatives: []string{"D"},
+		},
+		{
+			from:         "Ab",
+			to:           "Gb",
+			steps:        -2,
+			relationship: harmony.RelationshipDistant,
+			pivots:       []string{"Bbm", "Db"},
+			dominant:     "Db7",
+			alternatives: []string{"Ab"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.from+"->"+tc.to, func(t *testing.T) {
+			from, err := theory.ParseKey(tc.from)
+			require.Nil(t, err)
+			to, err := theory.ParseKey(tc.to)
+			require.Nil(t, err)
+
+			actual := harmony.PlanTransition(from.Key, to.Key)
+			require.Equal(t, tc.steps, actual.Steps)
+			require.Equal(t, tc.relationship, actual.Relationship)
+			require.Equal(t, tc.dominant, theory.NameChord(actual.Dominant))
+
+			var pivots []string
+			for _, c := range actual.Pivots {
+				pivots = append(pivots, theory.NameChord(c))
+			}
+			require.Equal(t, tc.pivots, pivots)
+
+			var alternatives []string
+			for _, k := range actual.Alternatives {
+				alternatives = append(alternatives, theory.NameKey(k))
+			}
+			require.Equal(t, tc.alternatives, alternatives)
+		})
+	}
+}
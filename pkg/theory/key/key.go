@@ -62,6 +62,21 @@ type Key struct {
 	kind Kind
 }
 
+// Accidentals returns the number of sharps, when positive, or flats, when negative, in the key signature.
+func (k Key) Accidentals() int {
+	tonic := k.note
+	if k.kind == KindMinor {
+		tonic = tonic.Transpose(interval.Minor(2))
+	}
+
+	accidentals := 0
+	for _, ival := range interval.Scales.Ionian {
+		accidentals += tonic.Transpose(ival).Accidentals()
+	}
+
+	return accidentals
+}
+
 func (k Key) CompareTo(o Key) int {
 	comp := k.note.CompareTo(o.note)
 	if comp != 0 {
@@ -0,0 +1,43 @@
+package theory_test
+
+import (
+	"testing"
+
+	theory2 "github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/craiggwilson/songtool/pkg/theory/key"
+	"github.com/craiggwilson/songtool/pkg/theory/note"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKey(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected key.Key
+	}{
+		{
+			text:     "C",
+			expected: key.Major(note.C),
+		},
+		{
+			text:     "Am",
+			expected: key.Minor(note.A),
+		},
+		{
+			text:     "F#m",
+			expected: key.Minor(note.FSharp),
+		},
+		{
+			text:     "Eb-",
+			expected: key.Minor(note.EFlat),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			actual, err := theory2.ParseKey(tc.text)
+			require.Nil(t, err)
+			require.Equal(t, tc.expected, actual.Key)
+			require.Equal(t, tc.text, actual.Name)
+		})
+	}
+}
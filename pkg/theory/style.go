@@ -0,0 +1,52 @@
+package theory
+
+// ChordStyle controls the symbols used when naming chords. Empty symbols fall back to the first symbol configured for
+// that quality, and an empty half diminished symbol names half diminished chords as minor with a flat 5th.
+type ChordStyle struct {
+	MajorSymbol          string `json:"majorSymbol,omitempty"`
+	MinorSymbol          string `json:"minorSymbol,omitempty"`
+	AugmentedSymbol      string `json:"augmentedSymbol,omitempty"`
+	DiminishedSymbol     string `json:"diminishedSymbol,omitempty"`
+	HalfDiminishedSymbol string `json:"halfDiminishedSymbol,omitempty"`
+	// Altered names dominant chords with an altered 5th and an altered 9th as "7alt".
+	Altered bool `json:"altered,omitempty"`
+}
+
+// DefaultChordStyles are the chord styles available without any configuration.
+func DefaultChordStyles() map[string]ChordStyle {
+	return map[string]ChordStyle{
+		"pop": {},
+		"jazz": {
+			MajorSymbol:          "Δ",
+			MinorSymbol:          "-",
+			AugmentedSymbol:      "+",
+			DiminishedSymbol:     "°",
+			HalfDiminishedSymbol: "ø",
+			Altered:              true,
+		},
+		"classical": {
+			MajorSymbol:          "M",
+			MinorSymbol:          "m",
+			AugmentedSymbol:      "+",
+			DiminishedSymbol:     "°",
+			HalfDiminishedSymbol: "ø",
+		},
+	}
+}
+
+func (cs ChordStyle) withDefaults(cfg *Config) ChordStyle {
+	if len(cs.MajorSymbol) == 0 {
+		cs.MajorSymbol = cfg.MajorSymbols[0]
+	}
+	if len(cs.MinorSymbol) == 0 {
+		cs.MinorSymbol = cfg.MinorSymbols[0]
+	}
+	if len(cs.AugmentedSymbol) == 0 {
+		cs.AugmentedSymbol = cfg.AugmentedSymbols[0]
+	}
+	if len(cs.DiminishedSymbol) == 0 {
+		cs.DiminishedSymbol = cfg.DiminishedSymbols[0]
+	}
+
+	return cs
+}
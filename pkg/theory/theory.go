@@ -3,6 +3,7 @@ package theory
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/craiggwilson/songtool/pkg/theory/chord"
@@ -23,192 +24,112 @@ func Default() *Theory {
 }
 
 func New(cfg *Config) *Theory {
-	return &Theory{cfg: cfg}
-}
-
-type Theory struct {
-	cfg *Config
-}
-
-func (t *Theory) ListScales() []ScaleMeta {
-	result := make([]ScaleMeta, 0, len(t.cfg.Scales))
-	for k, v := range t.cfg.Scales {
-		result = append(result, ScaleMeta{k, v})
+	return &Theory{
+		cfg:   cfg,
+		style: cfg.ChordStyles[cfg.ChordStyle],
 	}
-
-	return result
 }
 
-func (t *Theory) LookupScale(name string) (ScaleMeta, bool) {
-	intervals, ok := t.cfg.Scales[name]
-	if !ok {
-		return ScaleMeta{}, false
-	}
-
-	return ScaleMeta{name, intervals}, true
+type Theory struct {
+	cfg   *Config
+	style ChordStyle
 }
 
-func (t *Theory) NameChord(c chord.Chord) string {
-	suffix := ""
-	steps := interval.Steps(c.Intervals())
-
-	halfDim := func() bool {
-		return steps[3] && steps[6] && steps[10] // 3m b5 m7
-	}
-
-	m := func() bool {
-		return steps[3] && steps[7] || halfDim() // 3m 5P
-	}
-
-	dim7 := func() bool {
-		return steps[3] && steps[6] && steps[9] // 3m b5 7d
-	}
-
-	dim := func() bool {
-		return steps[3] && steps[6] // 3m b5
-	}
-
-	aug := func() bool {
-		return steps[4] && steps[8] && !steps[7] && !steps[11] // 3M 5a !5P !7M
-	}
-
-	no3 := func() bool {
-		return !steps[3] && !steps[4] // !3m !3M
+// AlternativeChords finds other ways of naming the same set of notes, keeping the lowest note in the bass. For
+// example, Am7/C can also be named C6. Only interpretations with a 3rd and a 5th are considered, and the results are
+// ordered from the simplest name to the most complex.
+func (t *Theory) AlternativeChords(c chord.Chord) []chord.Chord {
+	notes := c.Notes()
+	bass := c.Root()
+	if base := c.Base(); base != nil {
+		bass = *base
+		notes = append(notes, bass)
 	}
 
-	simple6 := func() bool {
-		return steps[9] && !steps[2] && !steps[5] && !dim() // 6M !2M !4P
+	pitchClasses := make(map[int]struct{}, len(notes))
+	for _, n := range notes {
+		pitchClasses[n.PitchClass()] = struct{}{}
 	}
 
-	any7 := func() bool {
-		return steps[10] || steps[11] // 7m || 7M
-	}
+	name := t.NameChord(c)
+	seen := map[string]struct{}{name: {}}
 
-	maybeParens := func(s string) string {
-		if len(suffix) > 0 {
-			return s
+	var alternatives []chord.Chord
+	for _, root := range notes {
+		if root.PitchClass() == c.Root().PitchClass() || abs(normalizeAccidentals(root.Accidentals())) > 1 {
+			continue
 		}
 
-		return "(" + s + ")"
-	}
-
-	sharp := func(s string) string {
-		return t.cfg.SharpSymbols[0] + s
-	}
-
-	flat := func(s string) string {
-		return t.cfg.FlatSymbols[0] + s
-	}
-
-	// Quality
-	switch {
-	case m():
-		suffix += t.cfg.MinorSymbols[0]
-	case dim7():
-		suffix += t.cfg.DiminishedSymbols[0] + "7"
-	case dim():
-		suffix += t.cfg.DiminishedSymbols[0]
-	case aug():
-		suffix += t.cfg.AugmentedSymbols[0]
-	}
-
-	if steps[10] { // 7m
-		num := "7"
-		if steps[21] { // 13M
-			num = "13"
-		} else if steps[17] { // 11P
-			num = "11"
-		} else if steps[14] { // 9M
-			num = "9"
+		intervals, ok := intervalsFromPitchClasses(root, pitchClasses)
+		if !ok {
+			continue
 		}
 
-		suffix += num
-	}
-
-	if no3() {
-		if steps[2] { // 2M
-			suffix += "2"
+		var base *note.Note
+		if bass.PitchClass() != root.PitchClass() {
+			base = &bass
 		}
-		if steps[5] { // 4P
-			suffix += "sus"
-		}
-	}
 
-	if simple6() {
-		suffix += "6"
-	}
-
-	if steps[11] { // 7M
-		num := "7"
-		if steps[21] { // 13M
-			num = "13"
-		} else if steps[17] { // 11P
-			num = "11"
-		} else if steps[14] { // 9M
-			num = "9"
+		alt := chord.New(root, base, intervals...)
+		altName := t.NameChord(alt)
+		if _, ok := seen[altName]; ok {
+			continue
 		}
+		seen[altName] = struct{}{}
 
-		suffix += t.cfg.MajorSymbols[0] + num
-	}
-
-	if !no3() {
-		if steps[2] { // 2M
-			suffix += "add2"
-		}
-		if steps[5] { // 4P
-			suffix += "add4"
+		// Only keep names which describe exactly the same notes when parsed back.
+		parsed, err := t.ParseChord(altName)
+		if err != nil || !samePitchClasses(parsed.Chord, pitchClasses) {
+			continue
 		}
-	}
 
-	if steps[9] && !simple6() && !dim7() {
-		suffix += "add6"
+		alternatives = append(alternatives, alt)
 	}
 
-	if !any7() {
-		if steps[14] { // 9M
-			suffix += "add9"
-		}
-		if steps[17] { // 11P
-			suffix += "add11"
-		}
-		if steps[21] { // 13M
-			suffix += "add13"
-		}
-	}
+	sort.SliceStable(alternatives, func(i, j int) bool {
+		return len(t.NameChord(alternatives[i])) < len(t.NameChord(alternatives[j]))
+	})
 
-	if steps[6] && (halfDim() || !dim()) { // 5d
-		suffix += maybeParens(flat("5"))
-	}
+	return alternatives
+}
 
-	if steps[8] && !aug() {
-		if steps[3] { // 3m
-			suffix += maybeParens(flat("6"))
-		} else {
-			suffix += maybeParens(sharp("5"))
-		}
+func (t *Theory) ListChordStyles() []string {
+	names := make([]string, 0, len(t.cfg.ChordStyles))
+	for name := range t.cfg.ChordStyles {
+		names = append(names, name)
 	}
 
-	if steps[13] { // 9m
-		suffix += maybeParens(flat("9"))
-	}
+	sort.Strings(names)
+	return names
+}
 
-	if steps[15] { // 9a
-		suffix += maybeParens(sharp("9"))
+func (t *Theory) ListScales() []ScaleMeta {
+	result := make([]ScaleMeta, 0, len(t.cfg.Scales))
+	for k, v := range t.cfg.Scales {
+		result = append(result, ScaleMeta{k, v})
 	}
 
-	if steps[18] { // 11a
-		suffix += maybeParens(sharp("11"))
-	}
+	return result
+}
 
-	if no3() && len(suffix) == 0 {
-		suffix += "5"
-	}
+func (t *Theory) LookupChordStyle(name string) (ChordStyle, bool) {
+	style, ok := t.cfg.ChordStyles[name]
+	return style, ok
+}
 
-	if base := c.Base(); base != nil {
-		suffix += t.cfg.BaseNoteDelimiters[0] + t.NameNote(*base)
+func (t *Theory) LookupScale(name string) (ScaleMeta, bool) {
+	intervals, ok := t.cfg.Scales[name]
+	if !ok {
+		return ScaleMeta{}, false
 	}
 
-	return t.NameNote(c.Root()) + suffix
+	return ScaleMeta{name, intervals}, true
+}
+
+// NameChord names the chord in the current chord style.
+func (t *Theory) NameChord(c chord.Chord) string {
+	root, suffix, delim, base := t.nameChordParts(c)
+	return root + suffix + delim + base
 }
 
 func (t *Theory) NameKey(k key.Key) string {
@@ -242,12 +163,33 @@ func (t *Theory) NameNote(n note.Note) string {
 	return natural + accidentalStr
 }
 
+// NormalizeChord renames the chord in the current chord style, discarding how it was originally written.
+func (t *Theory) NormalizeChord(c chord.Chord) chord.Named {
+	if base := c.Base(); base != nil && base.PitchClass() == c.Root().PitchClass() {
+		c = chord.New(c.Root(), nil, c.Intervals()...)
+	}
+
+	root, suffix, delim, base := t.nameChordParts(c)
+	return chord.Named{
+		Parsed: chord.Parsed{
+			Chord:             c,
+			Suffix:            suffix,
+			BaseNoteDelimiter: delim,
+		},
+		Name: root + suffix + delim + base,
+	}
+}
+
 func (t *Theory) ParseChord(text string) (chord.Named, error) {
 	root, pos, err := t.parseNote(text, 0)
 	if err != nil {
 		return chord.Named{}, err
 	}
 
+	original := text
+	expanded, offsets := t.expandChordAliases(text[pos:])
+	text = text[:pos] + expanded
+
 	intervalMap := make(map[interval.Interval]struct{})
 
 	suffix := text[pos:]
@@ -308,10 +250,10 @@ func (t *Theory) ParseChord(text string) (chord.Named, error) {
 	return chord.Named{
 		Parsed: chord.Parsed{
 			Chord:             chord.New(root, base, intervals...),
-			Suffix:            text[suffixPos:delimiterPos],
+			Suffix:            original[suffixPos : suffixPos+offsets[delimiterPos-suffixPos]],
 			BaseNoteDelimiter: delim,
 		},
-		Name: text,
+		Name: original,
 	}, nil
 }
 
@@ -352,7 +294,7 @@ func (t *Theory) ParseKey(text string) (key.Named, error) {
 			Key:    key.New(n, kind),
 			Suffix: suffix,
 		},
-		Name: text,
+		Name: text + suffix,
 	}, nil
 }
 
@@ -390,6 +332,19 @@ func (t *Theory) ParseScale(text string) (scale.Scale, error) {
 	return scale.Generate(fmt.Sprintf("%s %s", parts[0], meta.Name), root, meta.Intervals...), nil
 }
 
+// WithChordStyle returns a copy of the theory that names chords using the named chord style.
+func (t *Theory) WithChordStyle(name string) (*Theory, error) {
+	style, ok := t.LookupChordStyle(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown chord style %q", name)
+	}
+
+	return &Theory{
+		cfg:   t.cfg,
+		style: style,
+	}, nil
+}
+
 func (t *Theory) degreeClassFromNaturalNoteName(naturalNoteName string) int {
 	for i, nn := range t.cfg.NaturalNoteNames {
 		if nn == naturalNoteName {
@@ -400,6 +355,220 @@ func (t *Theory) degreeClassFromNaturalNoteName(naturalNoteName string) int {
 	panic(fmt.Sprintf("natural note name %q does not map to a degree class", naturalNoteName))
 }
 
+// expandChordAliases replaces the aliases in the text with what they stand for. It also returns, for each byte of the
+// expanded text and for its end, the offset in the text it came from, so that positions in the expanded text can be
+// mapped back.
+func (t *Theory) expandChordAliases(text string) (string, []int) {
+	var sb strings.Builder
+	offsets := make([]int, 0, len(text)+1)
+	for i := 0; i < len(text); {
+		matched := false
+		for _, alias := range t.cfg.chordAliases {
+			if strings.HasPrefix(text[i:], alias.symbol) {
+				sb.WriteString(alias.suffix)
+				for j := 0; j < len(alias.suffix); j++ {
+					offsets = append(offsets, i)
+				}
+				i += len(alias.symbol)
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			sb.WriteByte(text[i])
+			offsets = append(offsets, i)
+			i++
+		}
+	}
+
+	offsets = append(offsets, len(text))
+	return sb.String(), offsets
+}
+
+// nameChordParts names the chord in the current chord style, returning the root, suffix, base note delimiter and
+// base note names separately.
+func (t *Theory) nameChordParts(c chord.Chord) (string, string, string, string) {
+	suffix := ""
+	steps := interval.Steps(c.Intervals())
+	style := t.style.withDefaults(t.cfg)
+
+	halfDim := func() bool {
+		return steps[3] && steps[6] && steps[10] // 3m b5 m7
+	}
+
+	m := func() bool {
+		return steps[3] && steps[7] || halfDim() // 3m 5P
+	}
+
+	dim7 := func() bool {
+		return steps[3] && steps[6] && steps[9] // 3m b5 7d
+	}
+
+	dim := func() bool {
+		return steps[3] && steps[6] // 3m b5
+	}
+
+	aug := func() bool {
+		return steps[4] && steps[8] && !steps[7] && !steps[11] // 3M 5a !5P !7M
+	}
+
+	no3 := func() bool {
+		return !steps[3] && !steps[4] // !3m !3M
+	}
+
+	simple6 := func() bool {
+		return steps[9] && !steps[2] && !steps[5] && !dim() // 6M !2M !4P
+	}
+
+	any7 := func() bool {
+		return steps[10] || steps[11] // 7m || 7M
+	}
+
+	maybeParens := func(s string) string {
+		if len(suffix) > 0 {
+			return s
+		}
+
+		return "(" + s + ")"
+	}
+
+	sharp := func(s string) string {
+		return t.cfg.SharpSymbols[0] + s
+	}
+
+	flat := func(s string) string {
+		return t.cfg.FlatSymbols[0] + s
+	}
+
+	useHalfDim := len(style.HalfDiminishedSymbol) > 0 && halfDim()
+
+	alt := func() bool {
+		return steps[4] && steps[10] && !steps[7] && steps[6] && steps[8] && steps[13] && steps[15] // 3M 7m !5P b5 #5 b9 #9
+	}
+
+	if style.Altered && alt() {
+		return t.nameChordBase(c, "7alt")
+	}
+
+	// Quality
+	switch {
+	case useHalfDim:
+		suffix += style.HalfDiminishedSymbol
+	case m():
+		suffix += style.MinorSymbol
+	case dim7():
+		suffix += style.DiminishedSymbol + "7"
+	case dim():
+		suffix += style.DiminishedSymbol
+	case aug():
+		suffix += style.AugmentedSymbol
+	}
+
+	if steps[10] { // 7m
+		num := "7"
+		if steps[21] { // 13M
+			num = "13"
+		} else if steps[17] { // 11P
+			num = "11"
+		} else if steps[14] { // 9M
+			num = "9"
+		}
+
+		suffix += num
+	}
+
+	if no3() {
+		if steps[2] { // 2M
+			suffix += "2"
+		}
+		if steps[5] { // 4P
+			suffix += "sus"
+		}
+	}
+
+	if simple6() {
+		suffix += "6"
+	}
+
+	if steps[11] { // 7M
+		num := "7"
+		if steps[21] { // 13M
+			num = "13"
+		} else if steps[17] { // 11P
+			num = "11"
+		} else if steps[14] { // 9M
+			num = "9"
+		}
+
+		suffix += style.MajorSymbol + num
+	}
+
+	if !no3() {
+		if steps[2] { // 2M
+			suffix += "add2"
+		}
+		if steps[5] { // 4P
+			suffix += "add4"
+		}
+	}
+
+	if steps[9] && !simple6() && !dim7() {
+		suffix += "add6"
+	}
+
+	if !any7() {
+		if steps[14] { // 9M
+			suffix += "add9"
+		}
+		if steps[17] { // 11P
+			suffix += "add11"
+		}
+		if steps[21] { // 13M
+			suffix += "add13"
+		}
+	}
+
+	if steps[6] && !useHalfDim && (halfDim() || !dim()) { // 5d
+		suffix += maybeParens(flat("5"))
+	}
+
+	if steps[8] && !aug() {
+		if steps[3] { // 3m
+			suffix += maybeParens(flat("6"))
+		} else {
+			suffix += maybeParens(sharp("5"))
+		}
+	}
+
+	if steps[13] { // 9m
+		suffix += maybeParens(flat("9"))
+	}
+
+	if steps[15] { // 9a
+		suffix += maybeParens(sharp("9"))
+	}
+
+	if steps[18] { // 11a
+		suffix += maybeParens(sharp("11"))
+	}
+
+	if no3() && len(suffix) == 0 {
+		suffix += "5"
+	}
+
+	return t.nameChordBase(c, suffix)
+}
+
+func (t *Theory) nameChordBase(c chord.Chord, suffix string) (string, string, string, string) {
+	root := t.NameNote(c.Root())
+	if base := c.Base(); base != nil && base.PitchClass() != c.Root().PitchClass() {
+		return root, suffix, t.cfg.BaseNoteDelimiters[0], t.NameNote(*base)
+	}
+
+	return root, suffix, "", ""
+}
+
 func (t *Theory) parseAccidentals(text string, pos int) (int, int) {
 	if len(text) <= pos {
 		return 0, pos
@@ -1,3 +1,109 @@
 package theory
 
+import (
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/interval"
+	"github.com/craiggwilson/songtool/pkg/theory/note"
+)
+
 var degreeClassToPitchClass = [7]int{0, 2, 4, 5, 7, 9, 11}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// intervalsFromPitchClasses interprets the pitch classes as a chord built on root. It fails when the result would
+// not have both a 3rd and a 5th.
+func intervalsFromPitchClasses(root note.Note, pitchClasses map[int]struct{}) ([]interval.Interval, bool) {
+	var offsets [12]bool
+	for pc := range pitchClasses {
+		offsets[(pc-root.PitchClass()+12)%12] = true
+	}
+
+	intervals := []interval.Interval{interval.Perfect(0)}
+	switch {
+	case offsets[4]:
+		intervals = append(intervals, interval.Major(2))
+		if offsets[3] {
+			intervals = append(intervals, interval.Augmented(8, 1))
+		}
+	case offsets[3]:
+		intervals = append(intervals, interval.Minor(2))
+	default:
+		return nil, false
+	}
+
+	has7th := offsets[10] || offsets[11]
+	switch {
+	case offsets[7]:
+		intervals = append(intervals, interval.Perfect(4))
+		if offsets[6] {
+			intervals = append(intervals, interval.Augmented(10, 1))
+		}
+		if offsets[8] {
+			intervals = append(intervals, interval.Minor(5))
+		}
+	case offsets[6] && !offsets[4]:
+		intervals = append(intervals, interval.Diminished(4, 1))
+	case offsets[8] && offsets[4]:
+		intervals = append(intervals, interval.Augmented(4, 1))
+	default:
+		return nil, false
+	}
+
+	if offsets[1] {
+		intervals = append(intervals, interval.Minor(8))
+	}
+	if offsets[2] {
+		intervals = append(intervals, interval.Major(8))
+	}
+	if offsets[5] {
+		intervals = append(intervals, interval.Perfect(10))
+	}
+	if offsets[9] {
+		if has7th {
+			intervals = append(intervals, interval.Major(12))
+		} else {
+			intervals = append(intervals, interval.Major(5))
+		}
+	}
+	if offsets[10] {
+		intervals = append(intervals, interval.Minor(6))
+	}
+	if offsets[11] {
+		intervals = append(intervals, interval.Major(6))
+	}
+
+	interval.Sort(intervals)
+	return intervals, true
+}
+
+func normalizeAccidentals(accidentals int) int {
+	if accidentals > 6 {
+		return accidentals - 12
+	} else if accidentals < -6 {
+		return accidentals + 12
+	}
+
+	return accidentals
+}
+
+func samePitchClasses(c chord.Chord, pitchClasses map[int]struct{}) bool {
+	notes := c.Notes()
+	if base := c.Base(); base != nil {
+		notes = append(notes, *base)
+	}
+
+	found := make(map[int]struct{}, len(notes))
+	for _, n := range notes {
+		if _, ok := pitchClasses[n.PitchClass()]; !ok {
+			return false
+		}
+		found[n.PitchClass()] = struct{}{}
+	}
+
+	return len(found) == len(pitchClasses)
+}
@@ -0,0 +1,77 @@
+package voicing
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/craiggwilson/songtool/pkg/theory/note"
+)
+
+var degreeClassToPitchClass = [7]int{0, 2, 4, 5, 7, 9, 11}
+
+// FromPitch creates a Voice for the absolute pitch, spelled using the provided note.
+func FromPitch(n note.Note, pitch int) Voice {
+	v := Voice{Note: n}
+	v.Octave = (pitch - v.Pitch()) / 12
+	return v
+}
+
+// ParseVoice parses a note name followed by an octave number, such as "C4" or "Bb2".
+func ParseVoice(parser note.Parser, text string) (Voice, error) {
+	idx := len(text)
+	for idx > 0 && text[idx-1] >= '0' && text[idx-1] <= '9' {
+		idx--
+	}
+
+	if idx > 0 && text[idx-1] == '-' && idx < len(text) {
+		idx--
+	}
+
+	if idx == len(text) {
+		return Voice{}, fmt.Errorf("expected an octave number at position %d in %q", idx, text)
+	}
+
+	n, err := parser.ParseNote(text[:idx])
+	if err != nil {
+		return Voice{}, err
+	}
+
+	octave, err := strconv.Atoi(text[idx:])
+	if err != nil {
+		return Voice{}, fmt.Errorf("invalid octave %q: %w", text[idx:], err)
+	}
+
+	return Voice{Note: n, Octave: octave}, nil
+}
+
+// Voice is a note placed in a specific octave. Octaves follow scientific pitch notation, so C4 is middle C.
+type Voice struct {
+	Note   note.Note
+	Octave int
+}
+
+func (v Voice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Note   note.Note `json:"note"`
+		Octave int       `json:"octave"`
+		Pitch  int       `json:"pitch"`
+	}{v.Note, v.Octave, v.Pitch()})
+}
+
+func (v Voice) Name(namer note.Namer) string {
+	return namer.NameNote(v.Note) + strconv.Itoa(v.Octave)
+}
+
+// Pitch is the absolute pitch of the voice, where C4 is 60.
+func (v Voice) Pitch() int {
+	stdPitchClass := degreeClassToPitchClass[v.Note.DegreeClass()]
+	accidentals := v.Note.Accidentals()
+	if accidentals > 6 {
+		accidentals -= 12
+	} else if accidentals < -6 {
+		accidentals += 12
+	}
+
+	return (v.Octave+1)*12 + stdPitchClass + accidentals
+}
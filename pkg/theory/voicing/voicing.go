@@ -0,0 +1,296 @@
+package voicing
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/note"
+)
+
+// DefaultRange is the range used for voicings when none is specified; C3 to G5.
+var DefaultRange = Range{
+	Low:  Voice{Note: note.C, Octave: 3},
+	High: Voice{Note: note.G, Octave: 5},
+}
+
+// Range is the inclusive span of pitches that voicings may occupy.
+type Range struct {
+	Low  Voice
+	High Voice
+}
+
+func (r Range) contains(pitch int) bool {
+	return pitch >= r.Low.Pitch() && pitch <= r.High.Pitch()
+}
+
+func (r Range) center() int {
+	return (r.Low.Pitch() + r.High.Pitch()) / 2
+}
+
+// Voicing is a chord with each of its notes placed in an octave, ordered from lowest to highest.
+type Voicing struct {
+	Chord     chord.Chord
+	Voices    []Voice
+	Inversion int
+}
+
+func (v Voicing) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Chord     chord.Chord `json:"chord"`
+		Voices    []Voice     `json:"voices"`
+		Inversion int         `json:"inversion"`
+	}{v.Chord, v.Voices, v.Inversion})
+}
+
+func (v Voicing) pitches() []int {
+	pitches := make([]int, len(v.Voices))
+	for i, voice := range v.Voices {
+		pitches[i] = voice.Pitch()
+	}
+
+	return pitches
+}
+
+// Candidates lists every close-position voicing of the chord, for each inversion, that fits in the range.
+// Chords with a base note always keep the base note as the lowest voice, with any inversion of the remaining notes
+// stacked above it.
+func Candidates(c chord.Chord, r Range) []Voicing {
+	tones := uniqueTones(c.Notes())
+	if len(tones) == 0 {
+		return nil
+	}
+
+	var candidates []Voicing
+	addStacks := func(bass note.Note, inversion int, upper []note.Note, rotations int) {
+		for rotation := 0; rotation < rotations; rotation++ {
+			notes := make([]note.Note, 0, len(upper)+1)
+			notes = append(notes, bass)
+			for i := range upper {
+				notes = append(notes, upper[(rotation+i)%len(upper)])
+			}
+
+			for octave := r.Low.Octave - 1; octave <= r.High.Octave; octave++ {
+				if v, ok := stack(c, inversion, notes, Voice{Note: bass, Octave: octave}, r); ok {
+					candidates = append(candidates, v)
+				}
+			}
+		}
+	}
+
+	if base := c.Base(); base != nil {
+		var upper []note.Note
+		for _, tone := range tones {
+			if tone.PitchClass() != base.PitchClass() {
+				upper = append(upper, tone)
+			}
+		}
+
+		addStacks(*base, int(c.Inversion()), upper, max(1, len(upper)))
+		return candidates
+	}
+
+	for i, bass := range tones {
+		upper := make([]note.Note, 0, len(tones)-1)
+		upper = append(upper, tones[i+1:]...)
+		upper = append(upper, tones[:i]...)
+		inverted := chord.New(c.Root(), &bass, c.Intervals()...)
+		addStacks(bass, int(inverted.Inversion()), upper, 1)
+	}
+
+	return candidates
+}
+
+// Lead chooses a voicing for each chord such that the total movement of the voices between consecutive chords is
+// minimized. Ties are broken in favor of fewer inversions, and then a first voicing nearer the center of the range.
+func Lead(chords []chord.Chord, r Range) ([]Voicing, error) {
+	if len(chords) == 0 {
+		return nil, nil
+	}
+
+	candidates := make([][]Voicing, len(chords))
+	for i, c := range chords {
+		candidates[i] = Candidates(c, r)
+		if len(candidates[i]) == 0 {
+			return nil, fmt.Errorf("chord %d has no voicings within the range", i)
+		}
+	}
+
+	costs := make([][]cost, len(chords))
+	prev := make([][]int, len(chords))
+
+	costs[0] = make([]cost, len(candidates[0]))
+	prev[0] = make([]int, len(candidates[0]))
+	for j, v := range candidates[0] {
+		costs[0][j] = cost{
+			penalty:  penalty(v),
+			distance: abs(mean(v.pitches()) - r.center()),
+		}
+		prev[0][j] = -1
+	}
+
+	for i := 1; i < len(chords); i++ {
+		costs[i] = make([]cost, len(candidates[i]))
+		prev[i] = make([]int, len(candidates[i]))
+		for j, v := range candidates[i] {
+			pitches := v.pitches()
+			best := -1
+			var bestCost cost
+			for k, pv := range candidates[i-1] {
+				c := cost{
+					movement: costs[i-1][k].movement + Movement(pv.pitches(), pitches),
+					penalty:  costs[i-1][k].penalty + penalty(v),
+					distance: costs[i-1][k].distance,
+				}
+				if best == -1 || c.less(bestCost) {
+					best = k
+					bestCost = c
+				}
+			}
+
+			costs[i][j] = bestCost
+			prev[i][j] = best
+		}
+	}
+
+	last := len(chords) - 1
+	best := 0
+	for j := range costs[last] {
+		if costs[last][j].less(costs[last][best]) {
+			best = j
+		}
+	}
+
+	result := make([]Voicing, len(chords))
+	for i := last; i >= 0; i-- {
+		result[i] = candidates[i][best]
+		best = prev[i][best]
+	}
+
+	return result, nil
+}
+
+// Movement is the number of half steps the voices travel when moving from one set of pitches to another. When the
+// number of voices differs, each voice of the larger set is matched with the nearest voice of the smaller set.
+func Movement(from, to []int) int {
+	if len(from) == len(to) {
+		total := 0
+		for i := range from {
+			total += abs(from[i] - to[i])
+		}
+		return total
+	}
+
+	larger, smaller := from, to
+	if len(larger) < len(smaller) {
+		larger, smaller = smaller, larger
+	}
+
+	total := 0
+	for _, p := range larger {
+		nearest := -1
+		for _, q := range smaller {
+			if d := abs(p - q); nearest == -1 || d < nearest {
+				nearest = d
+			}
+		}
+		total += nearest
+	}
+
+	return total
+}
+
+type cost struct {
+	movement int
+	penalty  int
+	// distance is how far the first voicing is from the center of the range.
+	distance int
+}
+
+func (c cost) less(o cost) bool {
+	if c.movement != o.movement {
+		return c.movement < o.movement
+	}
+	if c.penalty != o.penalty {
+		return c.penalty < o.penalty
+	}
+
+	return c.distance < o.distance
+}
+
+func penalty(v Voicing) int {
+	if v.Inversion == 0 {
+		return 0
+	}
+
+	return 1
+}
+
+func stack(c chord.Chord, inversion int, notes []note.Note, bass Voice, r Range) (Voicing, bool) {
+	voices := make([]Voice, 0, len(notes))
+	voices = append(voices, bass)
+	pitch := bass.Pitch()
+	if !r.contains(pitch) {
+		return Voicing{}, false
+	}
+
+	for _, n := range notes[1:] {
+		next := FromPitch(n, pitch-pitch%12+(Voice{Note: n}).Pitch()%12)
+		for next.Pitch() <= pitch {
+			next.Octave++
+		}
+		pitch = next.Pitch()
+		if !r.contains(pitch) {
+			return Voicing{}, false
+		}
+
+		voices = append(voices, next)
+	}
+
+	return Voicing{
+		Chord:     c,
+		Voices:    voices,
+		Inversion: inversion,
+	}, true
+}
+
+func uniqueTones(notes []note.Note) []note.Note {
+	seen := make(map[int]struct{}, len(notes))
+	tones := make([]note.Note, 0, len(notes))
+	for _, n := range notes {
+		if _, ok := seen[n.PitchClass()]; ok {
+			continue
+		}
+		seen[n.PitchClass()] = struct{}{}
+		tones = append(tones, n)
+	}
+
+	return tones
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func max(a, b int) int {
+	if a >= b {
+		return a
+	}
+	return b
+}
+
+func mean(values []int) int {
+	if len(values) == 0 {
+		return 0
+	}
+
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+
+	return total / len(values)
+}
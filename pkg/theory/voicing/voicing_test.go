@@ -0,0 +1,147 @@
+package voicing_test
+
+import (
+	"testing"
+
+	"github.com/craiggwilson/songtool/pkg/theory"
+	"github.com/craiggwilson/songtool/pkg/theory/chord"
+	"github.com/craiggwilson/songtool/pkg/theory/note"
+	"github.com/craiggwilson/songtool/pkg/theory/voicing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVoice_Pitch(t *testing.T) {
+	testCases := []struct {
+		voice    voicing.Voice
+		expected int
+	}{
+		{
+			voice:    voicing.Voice{Note: note.C, Octave: 4},
+			expected: 60,
+		},
+		{
+			voice:    voicing.Voice{Note: note.A, Octave: 4},
+			expected: 69,
+		},
+		{
+			voice:    voicing.Voice{Note: note.BSharp, Octave: 3},
+			expected: 60,
+		},
+		{
+			voice:    voicing.Voice{Note: note.CFlat, Octave: 4},
+			expected: 59,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.voice.Name(theory.Default()), func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.voice.Pitch())
+		})
+	}
+}
+
+func TestParseVoice(t *testing.T) {
+	testCases := []struct {
+		text           string
+		expected       voicing.Voice
+		expectedErrMsg string
+	}{
+		{
+			text:     "C4",
+			expected: voicing.Voice{Note: note.C, Octave: 4},
+		},
+		{
+			text:     "Bb2",
+			expected: voicing.Voice{Note: note.BFlat, Octave: 2},
+		},
+		{
+			text:     "F#-1",
+			expected: voicing.Voice{Note: note.FSharp, Octave: -1},
+		},
+		{
+			text:           "C",
+			expectedErrMsg: `expected an octave number at position 1 in "C"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			actual, err := voicing.ParseVoice(theory.Default(), tc.text)
+			if len(tc.expectedErrMsg) > 0 {
+				require.EqualError(t, err, tc.expectedErrMsg)
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestLead(t *testing.T) {
+	testCases := []struct {
+		name     string
+		chords   []string
+		expected [][]string
+	}{
+		{
+			name:   "I vi IV V",
+			chords: []string{"C", "Am", "F", "G"},
+			expected: [][]string{
+				{"G3", "C4", "E4"},
+				{"A3", "C4", "E4"},
+				{"A3", "C4", "F4"},
+				{"G3", "B3", "D4"},
+			},
+		},
+		{
+			name:   "root position over near the center",
+			chords: []string{"G"},
+			expected: [][]string{
+				{"G3", "B3", "D4"},
+			},
+		},
+		{
+			name:   "slash chord keeps its base",
+			chords: []string{"C", "G/B"},
+			expected: [][]string{
+				{"C4", "E4", "G4"},
+				{"B3", "D4", "G4"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			chords := make([]chord.Chord, 0, len(tc.chords))
+			for _, name := range tc.chords {
+				c, err := theory.ParseChord(name)
+				require.Nil(t, err)
+				chords = append(chords, c.Chord)
+			}
+
+			voicings, err := voicing.Lead(chords, voicing.DefaultRange)
+			require.Nil(t, err)
+			require.Len(t, voicings, len(tc.expected))
+
+			for i, v := range voicings {
+				names := make([]string, 0, len(v.Voices))
+				for _, voice := range v.Voices {
+					names = append(names, voice.Name(theory.Default()))
+				}
+				require.Equal(t, tc.expected[i], names)
+			}
+		})
+	}
+}
+
+func TestLead_OutOfRange(t *testing.T) {
+	c, err := theory.ParseChord("C")
+	require.Nil(t, err)
+
+	_, err = voicing.Lead([]chord.Chord{c.Chord}, voicing.Range{
+		Low:  voicing.Voice{Note: note.C, Octave: 4},
+		High: voicing.Voice{Note: note.D, Octave: 4},
+	})
+	require.EqualError(t, err, "chord 0 has no voicings within the range")
+}